@@ -0,0 +1,301 @@
+package tensors
+
+import "math"
+
+// sigmoid is the logistic function, computed so that it does not overflow for large inputs.
+func sigmoid(x float64) float64 {
+	if x >= 0 {
+		return 1 / (1 + math.Exp(-x))
+	}
+
+	e := math.Exp(x)
+	return e / (1 + e)
+}
+
+func relu(x float64) float64 {
+	if x > 0 {
+		return x
+	}
+
+	return 0
+}
+
+// Sigmoid returns a new Tensor with the logistic function, 1 / (1 + e^-x), applied to each value.
+// It is computed so that large inputs of either sign do not overflow.
+func (t Tensor) Sigmoid() Tensor {
+	return t.Apply(sigmoid)
+}
+
+// SigmoidDeriv returns the derivative of Sigmoid, evaluated at each value of t.
+func (t Tensor) SigmoidDeriv() Tensor {
+	return t.Apply(func(x float64) float64 {
+		y := sigmoid(x)
+		return y * (1 - y)
+	})
+}
+
+// Tanh returns a new Tensor with the hyperbolic tangent applied to each value.
+func (t Tensor) Tanh() Tensor {
+	return t.Apply(math.Tanh)
+}
+
+// TanhDeriv returns the derivative of Tanh, evaluated at each value of t.
+func (t Tensor) TanhDeriv() Tensor {
+	return t.Apply(func(x float64) float64 {
+		y := math.Tanh(x)
+		return 1 - y*y
+	})
+}
+
+// ReLU returns a new Tensor with each negative value replaced by zero.
+func (t Tensor) ReLU() Tensor {
+	return t.Apply(relu)
+}
+
+// ReLUDeriv returns the derivative of ReLU, evaluated at each value of t. The derivative at zero
+// is taken to be zero.
+func (t Tensor) ReLUDeriv() Tensor {
+	return t.GreaterScalar(0)
+}
+
+// LeakyReLU returns a new Tensor with each negative value multiplied by alpha.
+func (t Tensor) LeakyReLU(alpha float64) Tensor {
+	return t.Apply(func(x float64) float64 {
+		if x > 0 {
+			return x
+		}
+
+		return alpha * x
+	})
+}
+
+// LeakyReLUDeriv returns the derivative of LeakyReLU, evaluated at each value of t. The derivative
+// at zero is taken to be alpha.
+func (t Tensor) LeakyReLUDeriv(alpha float64) Tensor {
+	return t.Apply(func(x float64) float64 {
+		if x > 0 {
+			return 1
+		}
+
+		return alpha
+	})
+}
+
+// GELU returns a new Tensor with the Gaussian Error Linear Unit applied to each value, using the
+// exact form: x * Φ(x), where Φ is the cumulative distribution function of the standard normal
+// distribution.
+func (t Tensor) GELU() Tensor {
+	return t.Apply(func(x float64) float64 {
+		return 0.5 * x * (1 + math.Erf(x/math.Sqrt2))
+	})
+}
+
+// GELUDeriv returns the derivative of GELU, evaluated at each value of t.
+func (t Tensor) GELUDeriv() Tensor {
+	return t.Apply(func(x float64) float64 {
+		cdf := 0.5 * (1 + math.Erf(x/math.Sqrt2))
+		pdf := math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+		return cdf + x*pdf
+	})
+}
+
+// eachLane calls fn for every one-dimensional 'lane' of the Interpreter along the given axis,
+// giving the index of the first value of the lane, the stride between its values, and its length.
+// The axis must be valid.
+func (in Interpreter) eachLane(axis int, fn func(start, stride, n int)) {
+	outer, inner := in.split(axis)
+	n := in.Dims[axis]
+
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			fn(o*n*inner+i, inner, n)
+		}
+	}
+}
+
+// LogSumExp returns log(sum(exp(x))) along the given axis, computed by first subtracting the
+// maximum so that it does not overflow. The result has the same dimensions as t, except that
+// Dims[axis] is 1.
+//
+// LogSumExp will panic with an AxisError if axis is out of bounds. LogSumExpSafe returns the error
+// instead.
+func (t Tensor) LogSumExp(axis int) Tensor {
+	return must(t.LogSumExpSafe(axis))
+}
+
+// LogSumExpSafe undergoes the same process as LogSumExp, but returns error instead of panicking.
+func (t Tensor) LogSumExpSafe(axis int) (Tensor, error) {
+	if err := checkLayout(t.Interpreter); err != nil {
+		return Tensor{}, opError("LogSumExp", err, t.Interpreter)
+	} else if err := t.checkAxis(axis); err != nil {
+		return Tensor{}, opError("LogSumExp", err, t.Interpreter)
+	}
+
+	dims := make([]int, len(t.Dims))
+	copy(dims, t.Dims)
+	dims[axis] = 1
+
+	// lanes are given in the same order as the values of the result
+	res := NewTensor(dims)
+	lane := 0
+	t.eachLane(axis, func(start, stride, n int) {
+		res.Values[lane] = logSumExp(t.Values, start, stride, n)
+		lane++
+	})
+
+	return res, nil
+}
+
+// logSumExp computes log(sum(exp(x))) for a single lane of values.
+func logSumExp(values []float64, start, stride, n int) float64 {
+	max := math.Inf(-1)
+	for a := 0; a < n; a++ {
+		max = math.Max(max, values[start+a*stride])
+	}
+
+	// all -Inf (or +Inf) values would otherwise give NaN
+	if math.IsInf(max, 0) {
+		return max
+	}
+
+	var sum float64
+	for a := 0; a < n; a++ {
+		sum += math.Exp(values[start+a*stride] - max)
+	}
+
+	return max + math.Log(sum)
+}
+
+// Softmax returns a new Tensor with the softmax function applied along the given axis, so that the
+// values along that axis are positive and sum to 1. The maximum along the axis is subtracted before
+// exponentiating, so that large values do not overflow.
+//
+// Softmax will panic with an AxisError if axis is out of bounds. SoftmaxSafe returns the error
+// instead.
+func (t Tensor) Softmax(axis int) Tensor {
+	return must(t.SoftmaxSafe(axis))
+}
+
+// SoftmaxSafe undergoes the same process as Softmax, but returns error instead of panicking.
+func (t Tensor) SoftmaxSafe(axis int) (Tensor, error) {
+	res, err := t.LogSoftmaxSafe(axis)
+	if err != nil {
+		return Tensor{}, opError("Softmax", err, t.Interpreter)
+	}
+
+	for i, v := range res.Values {
+		res.Values[i] = math.Exp(v)
+	}
+
+	return res, nil
+}
+
+// LogSoftmax returns a new Tensor with the logarithm of the softmax function applied along the
+// given axis. It is computed as x - LogSumExp(x), which is more accurate than taking the
+// logarithm of Softmax.
+//
+// LogSoftmax will panic with an AxisError if axis is out of bounds. LogSoftmaxSafe returns the
+// error instead.
+func (t Tensor) LogSoftmax(axis int) Tensor {
+	return must(t.LogSoftmaxSafe(axis))
+}
+
+// LogSoftmaxSafe undergoes the same process as LogSoftmax, but returns error instead of panicking.
+func (t Tensor) LogSoftmaxSafe(axis int) (Tensor, error) {
+	if err := checkLayout(t.Interpreter); err != nil {
+		return Tensor{}, opError("LogSoftmax", err, t.Interpreter)
+	} else if err := t.checkAxis(axis); err != nil {
+		return Tensor{}, opError("LogSoftmax", err, t.Interpreter)
+	}
+
+	res := Tensor{Interpreter: t.Interpreter, Values: make([]float64, len(t.Values))}
+	t.eachLane(axis, func(start, stride, n int) {
+		lse := logSumExp(t.Values, start, stride, n)
+		for a := 0; a < n; a++ {
+			i := start + a*stride
+			res.Values[i] = t.Values[i] - lse
+		}
+	})
+
+	return res, nil
+}
+
+// SoftmaxGrad returns the gradient with respect to the input of Softmax, given its output and the
+// gradient with respect to its output. Along each lane of the axis, this is: y * (g - sum(g * y)).
+//
+// SoftmaxGrad will panic with an AxisError if axis is out of bounds, or a ShapeMismatchError
+// naming gradOut as operand 1 if it does not have the same dimensions as out. SoftmaxGradSafe
+// returns these errors instead.
+func SoftmaxGrad(out, gradOut Tensor, axis int) Tensor {
+	return must(SoftmaxGradSafe(out, gradOut, axis))
+}
+
+// SoftmaxGradSafe undergoes the same process as SoftmaxGrad, but returns error instead of
+// panicking.
+func SoftmaxGradSafe(out, gradOut Tensor, axis int) (Tensor, error) {
+	if err := checkGrad(out, gradOut, axis); err != nil {
+		return Tensor{}, opError("SoftmaxGrad", err, out.Interpreter, gradOut.Interpreter)
+	}
+
+	res := Tensor{Interpreter: out.Interpreter, Values: make([]float64, len(out.Values))}
+	out.eachLane(axis, func(start, stride, n int) {
+		var dot float64
+		for a := 0; a < n; a++ {
+			i := start + a*stride
+			dot += gradOut.Values[i] * out.Values[i]
+		}
+
+		for a := 0; a < n; a++ {
+			i := start + a*stride
+			res.Values[i] = out.Values[i] * (gradOut.Values[i] - dot)
+		}
+	})
+
+	return res, nil
+}
+
+// LogSoftmaxGrad returns the gradient with respect to the input of LogSoftmax, given its output
+// and the gradient with respect to its output. Along each lane of the axis, this is:
+// g - exp(y) * sum(g).
+//
+// LogSoftmaxGrad will panic under the same conditions as SoftmaxGrad. LogSoftmaxGradSafe returns
+// these errors instead.
+func LogSoftmaxGrad(out, gradOut Tensor, axis int) Tensor {
+	return must(LogSoftmaxGradSafe(out, gradOut, axis))
+}
+
+// LogSoftmaxGradSafe undergoes the same process as LogSoftmaxGrad, but returns error instead of
+// panicking.
+func LogSoftmaxGradSafe(out, gradOut Tensor, axis int) (Tensor, error) {
+	if err := checkGrad(out, gradOut, axis); err != nil {
+		return Tensor{}, opError("LogSoftmaxGrad", err, out.Interpreter, gradOut.Interpreter)
+	}
+
+	res := Tensor{Interpreter: out.Interpreter, Values: make([]float64, len(out.Values))}
+	out.eachLane(axis, func(start, stride, n int) {
+		var sum float64
+		for a := 0; a < n; a++ {
+			sum += gradOut.Values[start+a*stride]
+		}
+
+		for a := 0; a < n; a++ {
+			i := start + a*stride
+			res.Values[i] = gradOut.Values[i] - math.Exp(out.Values[i])*sum
+		}
+	})
+
+	return res, nil
+}
+
+// checkGrad checks the arguments given to the gradient functions of operations along an axis.
+func checkGrad(out, gradOut Tensor, axis int) error {
+	if err := checkLayout(out.Interpreter, gradOut.Interpreter); err != nil {
+		return err
+	} else if err := out.checkAxis(axis); err != nil {
+		return err
+	} else if !Equals(out.Interpreter, gradOut.Interpreter) {
+		return ShapeMismatchError{1, -1, gradOut.Dims, out.Dims}
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package tensors
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// returns true if every value of a is within tol of the corresponding value of b
+func approxEqual(a, b Tensor, tol float64) bool {
+	if !Equals(a.Interpreter, b.Interpreter) {
+		return false
+	}
+
+	for i := range a.Values {
+		if !(math.Abs(a.Values[i]-b.Values[i]) <= tol) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// requires GradCheck
+func tActivation(t *testing.T) {
+	x := FromNested([]float64{-800, -2, -0.5, 0.5, 3, 800})
+
+	table := []struct {
+		name     string
+		fn       func(Tensor) Tensor
+		deriv    func(Tensor) Tensor
+		expected []float64
+	}{
+		{"Sigmoid", Tensor.Sigmoid, Tensor.SigmoidDeriv,
+			[]float64{0, 0.11920292202211755, 0.3775406687981454, 0.6224593312018546, 0.9525741268224334, 1}},
+		{"Tanh", Tensor.Tanh, Tensor.TanhDeriv,
+			[]float64{-1, -0.9640275800758169, -0.46211715726000974, 0.46211715726000974, 0.9950547536867305, 1}},
+		{"ReLU", Tensor.ReLU, Tensor.ReLUDeriv, []float64{0, 0, 0, 0.5, 3, 800}},
+		{"LeakyReLU",
+			func(t Tensor) Tensor { return t.LeakyReLU(0.1) },
+			func(t Tensor) Tensor { return t.LeakyReLUDeriv(0.1) },
+			[]float64{-80, -0.2, -0.05, 0.5, 3, 800}},
+		{"GELU", Tensor.GELU, Tensor.GELUDeriv,
+			[]float64{0, -0.04550026389635842, -0.15426876936299347, 0.34573123063700656, 2.99595030590511, 800}},
+	}
+
+	// values away from the kink in ReLU, and small enough for finite differences
+	inputs := []Tensor{FromNested([]float64{-3, -1.2, -0.3, 0.4, 1.7, 4})}
+
+	for _, tab := range table {
+		if !approxEqual(FromNested(tab.expected), tab.fn(x), 1e-12) {
+			t.Errorf("%s: Bad values. Expected %v, Got %v.", tab.name, tab.expected, tab.fn(x))
+		}
+
+		fn := func(ts []Tensor) float64 { return tab.fn(ts[0]).Sum() }
+		ms := GradCheck(fn, inputs, []Tensor{tab.deriv(inputs[0])}, 1e-6, 1e-6, nil)
+		handleReturn(t, tab.name, []GradMismatch(nil), ms, "Bad derivative.")
+	}
+}
+
+// requires Activation, Random
+func tSoftmax(t *testing.T) {
+	x := FromNested([][]float64{{1, 2, 3}, {1000, 1000, 1000}, {-1000, 0, 1000}})
+
+	res, err := x.SoftmaxSafe(0)
+	expected := FromNested([][]float64{
+		{0.09003057317038046, 0.24472847105479764, 0.6652409557748219},
+		{1.0 / 3, 1.0 / 3, 1.0 / 3},
+		{0, 0, 1},
+	})
+	if handleErrors(t, "Softmax", nil, err, "") && !approxEqual(expected, res, 1e-12) {
+		t.Errorf("Softmax: Bad values. Expected %v, Got %v.", expected, res)
+	}
+
+	res, err = x.LogSumExpSafe(0)
+	expected = FromNested([][]float64{{3.40760596444438}, {1000 + math.Log(3)}, {1000}})
+	if handleErrors(t, "LogSumExp", nil, err, "") && !approxEqual(expected, res, 1e-12) {
+		t.Errorf("LogSumExp: Bad values. Expected %v, Got %v.", expected, res)
+	}
+
+	res, err = x.LogSoftmaxSafe(1)
+	if handleErrors(t, "LogSoftmax", nil, err, "") && res.Values[6] != -2000 {
+		t.Errorf("LogSoftmax: Not computed stably. Expected -2000, Got %v.", res.Values[6])
+	}
+
+	inf := FromNested([]float64{math.Inf(-1), math.Inf(-1)})
+	handleReturn(t, "LogSumExp", []float64{math.Inf(-1)}, inf.LogSumExp(0).Values, "")
+
+	for _, axis := range []int{0, 1} {
+		_, err = x.SoftmaxSafe(axis + 2)
+		handleErrors(t, "Softmax", AxisError{}, err, "")
+		_, err = x.LogSumExpSafe(axis - 2)
+		handleErrors(t, "LogSumExp", AxisError{}, err, "")
+	}
+
+	// gradients, weighted so that the gradient of the sum isn't trivially zero
+	rng := rand.New(rand.NewSource(5))
+	inputs := []Tensor{NewRandom([]int{4, 3, 2}, Normal(0, 2), rng)}
+	weights := NewRandom([]int{4, 3, 2}, Normal(0, 1), rng)
+
+	for axis := 0; axis < 3; axis++ {
+		out := inputs[0].Softmax(axis)
+		grad, err := SoftmaxGradSafe(out, weights, axis)
+		if handleErrors(t, "SoftmaxGrad", nil, err, "") {
+			fn := func(ts []Tensor) float64 { return Mul(ts[0].Softmax(axis), weights).Sum() }
+			ms := GradCheck(fn, inputs, []Tensor{grad}, 1e-6, 1e-6, nil)
+			handleReturn(t, "SoftmaxGrad", []GradMismatch(nil), ms, "Axis: %d.", axis)
+		}
+
+		out = inputs[0].LogSoftmax(axis)
+		grad, err = LogSoftmaxGradSafe(out, weights, axis)
+		if handleErrors(t, "LogSoftmaxGrad", nil, err, "") {
+			fn := func(ts []Tensor) float64 { return Mul(ts[0].LogSoftmax(axis), weights).Sum() }
+			ms := GradCheck(fn, inputs, []Tensor{grad}, 1e-6, 1e-6, nil)
+			handleReturn(t, "LogSoftmaxGrad", []GradMismatch(nil), ms, "Axis: %d.", axis)
+		}
+	}
+
+	_, err = SoftmaxGradSafe(inputs[0], x, 0)
+	handleErrors(t, "SoftmaxGrad", ShapeMismatchError{}, err, "")
+	_, err = LogSoftmaxGradSafe(inputs[0], weights, 3)
+	handleErrors(t, "LogSoftmaxGrad", AxisError{}, err, "")
+}
+
+// requires Activation, Random, Arithmetic
+func tNorm(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	x := NewRandom([]int{5, 3, 4}, Normal(2, 3), rng)
+
+	check := func(name string, res Tensor, axes []int) {
+		dims, _ := res.reducedDims(axes)
+		count := float64(res.Size() / NewInterpreter(dims).Size())
+
+		mean := NewTensor(dims)
+		broadcastEach(res.Interpreter, []Interpreter{mean.Interpreter}, func(i int, idx []int) {
+			mean.Values[idx[0]] += res.Values[i] / count
+		})
+		variance := NewTensor(dims)
+		broadcastEach(res.Interpreter, []Interpreter{mean.Interpreter}, func(i int, idx []int) {
+			variance.Values[idx[0]] += res.Values[i] * res.Values[i] / count
+		})
+
+		if !approxEqual(mean, Zeros(dims), 1e-12) || !approxEqual(variance, Ones(dims), 1e-6) {
+			t.Errorf("%s: Not normalized. Axes: %v, Means: %v, Variances: %v.", name, axes, mean, variance)
+		}
+	}
+
+	res, stats, err := x.LayerNormSafe([]int{0, 2}, 1e-9)
+	if handleErrors(t, "LayerNorm", nil, err, "") {
+		check("LayerNorm", res, []int{0, 2})
+		handleReturn(t, "LayerNorm", []int{1, 3, 1}, stats.Mean.Dims, "")
+	}
+
+	res, stats, err = x.BatchNormSafe(1, 1e-9)
+	if handleErrors(t, "BatchNorm", nil, err, "") {
+		check("BatchNorm", res, []int{0, 2})
+
+		// with its own statistics, inference should be the same
+		inf, err := x.BatchNormInferenceSafe(stats.Mean, stats.Var, 1e-9)
+		if handleErrors(t, "BatchNormInference", nil, err, "") && !approxEqual(res, inf, 1e-12) {
+			t.Errorf("BatchNormInference: Did not match BatchNorm. Expected %v, Got %v.", res, inf)
+		}
+	}
+
+	// gradients of both, weighted so that they aren't trivially zero
+	weights := NewRandom(x.Dims, Normal(0, 1), rng)
+	for _, axes := range [][]int{{0}, {1, 2}, {0, 1, 2}} {
+		out, stats := x.LayerNorm(axes, 1e-5)
+		grad, err := NormGradSafe(out, weights, stats)
+		if handleErrors(t, "NormGrad", nil, err, "") {
+			fn := func(ts []Tensor) float64 {
+				out, _ := ts[0].LayerNorm(axes, 1e-5)
+				return Mul(out, weights).Sum()
+			}
+
+			ms := GradCheck(fn, []Tensor{x}, []Tensor{grad}, 1e-6, 1e-5, nil)
+			handleReturn(t, "NormGrad", []GradMismatch(nil), ms, "Axes: %v.", axes)
+		}
+	}
+
+	errTable := []struct {
+		axes []int
+		err  error
+	}{
+		{nil, ErrZeroDims},
+		{[]int{3}, AxisError{}},
+		{[]int{1, 1}, ErrDuplicateAxis},
+	}
+
+	for _, tab := range errTable {
+		_, _, err := x.LayerNormSafe(tab.axes, 1e-5)
+		handleErrors(t, "LayerNorm", tab.err, err, "Axes: %v.", tab.axes)
+	}
+
+	_, _, err = x.BatchNormSafe(-1, 1e-5)
+	handleErrors(t, "BatchNorm", AxisError{}, err, "")
+	_, _, err = Ones([]int{3}).BatchNormSafe(0, 1e-5)
+	handleErrors(t, "BatchNorm", ErrZeroDims, err, "")
+	_, err = x.BatchNormInferenceSafe(Zeros([]int{2}), Ones([]int{1, 3}), 1e-5)
+	handleErrors(t, "BatchNormInference", ShapeMismatchError{}, err, "")
+	_, err = NormGradSafe(x, weights, NormStats{Mean: Zeros([]int{2})})
+	handleErrors(t, "NormGrad", ShapeMismatchError{}, err, "")
+
+	out, stats := x.LayerNorm([]int{0}, 1e-5)
+	stats.Var = Ones([]int{1})
+	_, err = NormGradSafe(out, weights, stats)
+	var shape ShapeMismatchError
+	if handleErrors(t, "NormGrad", ShapeMismatchError{}, err, "Var.") &&
+		(!errors.As(err, &shape) || shape.Operand() != 3) {
+		t.Errorf("NormGrad: Expected stats.Var to be named as operand 3. Got %q.", err)
+	}
+}
@@ -11,6 +11,8 @@ func TestAll(t *testing.T) {
 
 	// interpreter_test.go functions
 	g.Require(tEquals, tNewInterpreter)
+	g.Require(tNewInterpreterLimit, tNewInterpreter)
+	g.Require(tValidate, tNewInterpreter)
 	g.Require(tCheckPoint, tNewInterpreter)
 	g.Require(tCheckIndex, tNewInterpreter)
 	g.Require(tIndex, tCheckPoint)
@@ -18,15 +20,111 @@ func TestAll(t *testing.T) {
 	g.Require(tIncrement, tCheckPoint)
 	g.Require(tDecrement, tCheckPoint)
 	g.Require(tIncreaseBy, tIncrement, tDecrement, tIndex, tPoint)
+	g.Require(tNewInterpreterExtended, tNewInterpreter, tIndex, tPoint, tIncrement, tMapApply)
 
 	// mapapply_test.go
 	g.Require(tMapApply, tIncreaseBy, tIncrement)
 
+	// layout_test.go
+	g.Require(tLayout, tIncreaseBy, tMapApply, tFromNested)
+	g.Require(tLayoutOps, tLayout, tArithmetic, tCompare, tWhere, tMasked, tSetRegion, tMatMul,
+		tCopyFrom, tScatter, tSparseOps, tOptimizer, tBackward)
+
+	// format_test.go
+	g.Require(tFormat, tNewInterpreter)
+
+	// constructors_test.go
+	g.Require(tConstructors, tNewInterpreter)
+	g.Require(tFromNested, tNewInterpreter)
+
+	// random_test.go
+	g.Require(tRandom, tNewInterpreter)
+	g.Require(tFillParallel, tRandom)
+
+	// concat_test.go
+	g.Require(tConcat, tFromNested, tConstructors)
+	g.Require(tStack, tConcat)
+	g.Require(tSplit, tConcat)
+
+	// gather_test.go
+	g.Require(tGather, tFromNested)
+	g.Require(tScatter, tGather, tConstructors)
+	g.Require(tIndexSelect, tFromNested)
+
+	// mask_test.go
+	g.Require(tCompare, tFromNested)
+	g.Require(tWhere, tCompare, tConstructors)
+	g.Require(tMasked, tCompare, tConstructors)
+
+	// conv_test.go
+	g.Require(tConv, tConstructors, tRandom)
+	g.Require(tConvGrad, tConv)
+
+	// pool_test.go
+	g.Require(tPool, tFromNested, tGather)
+	g.Require(tPoolGrad, tPool, tConstructors)
+
+	// pad_test.go
+	g.Require(tPad, tFromNested)
+	g.Require(tCrop, tPad)
+
+	// ops_test.go
+	g.Require(tArithmetic, tFromNested)
+	g.Require(tReshape, tFromNested)
+	g.Require(tMatMul, tFromNested, tConstructors)
+
+	// autodiff_test.go
+	g.Require(tAutodiff, tArithmetic, tReshape, tMatMul, tRandom)
+	g.Require(tBackward, tAutodiff)
+
+	// gradcheck_test.go
+	g.Require(tGradCheck, tAutodiff, tMapApply)
+
+	// activation_test.go
+	g.Require(tActivation, tGradCheck)
+	g.Require(tSoftmax, tActivation, tRandom)
+	g.Require(tNorm, tActivation, tRandom, tArithmetic)
+
+	// loss_test.go
+	g.Require(tLoss, tSoftmax)
+	g.Require(tLossGrad, tLoss, tGradCheck)
+
+	// optim_test.go
+	g.Require(tOptimizer, tFromNested)
+	g.Require(tOptimizerCheckpoint, tOptimizer)
+
+	// fft_test.go
+	g.Require(tFFT, tRandom)
+	g.Require(tRFFT, tFFT)
+	g.Require(tFFTConvolve, tFFT)
+
+	// sparse_test.go
+	g.Require(tSparse, tFromNested)
+	g.Require(tSparseMatMul, tSparse, tMatMul)
+	g.Require(tSparseOps, tSparse, tArithmetic)
+	g.Require(tSparseMapApply, tSparse, tMapApply)
+
+	// memory_test.go
+	g.Require(tMemoryPool, tNewInterpreter)
+	g.Require(tArena, tMemoryPool)
+
+	// cow_test.go
+	g.Require(tShare, tFromNested, tRandom, tScatter)
+
+	// tensors_test.go
+	g.Require(tClone, tFromNested)
+	g.Require(tCopyFrom, tClone, tShare)
+	g.Require(tSetPoint, tIndex, tShare)
+	g.Require(tSetRegion, tFromNested, tCrop)
+
 	g.NameAll([]struct {
 		Fn   func(*testing.T)
 		Name string
 	}{
 		{tNewInterpreter, "NewInterpreter"},
+		{tNewInterpreterLimit, "NewInterpreterLimit"},
+		{tNewInterpreterExtended, "NewInterpreterExtended"},
+		{tValidate, "Validate"},
 		{tEquals, "Equals"},
 		{tCheckPoint, "CheckPoint"},
 		{tCheckIndex, "CheckIndex"},
@@ -36,6 +134,55 @@ func TestAll(t *testing.T) {
 		{tDecrement, "Decrement"},
 		{tIncreaseBy, "IncreaseBy"},
 		{tMapApply, "MapApply"},
+		{tLayout, "Layout"},
+		{tLayoutOps, "LayoutOps"},
+		{tFormat, "Format"},
+		{tConstructors, "Constructors"},
+		{tFromNested, "FromNested"},
+		{tRandom, "Random"},
+		{tFillParallel, "FillParallel"},
+		{tConcat, "Concat"},
+		{tStack, "Stack"},
+		{tSplit, "Split"},
+		{tGather, "Gather"},
+		{tScatter, "Scatter"},
+		{tIndexSelect, "IndexSelect"},
+		{tCompare, "Compare"},
+		{tWhere, "Where"},
+		{tMasked, "Masked"},
+		{tConv, "Conv"},
+		{tConvGrad, "ConvGrad"},
+		{tPool, "Pool"},
+		{tPoolGrad, "PoolGrad"},
+		{tPad, "Pad"},
+		{tCrop, "Crop"},
+		{tArithmetic, "Arithmetic"},
+		{tReshape, "Reshape"},
+		{tMatMul, "MatMul"},
+		{tAutodiff, "Autodiff"},
+		{tBackward, "Backward"},
+		{tGradCheck, "GradCheck"},
+		{tActivation, "Activation"},
+		{tSoftmax, "Softmax"},
+		{tNorm, "Norm"},
+		{tLoss, "Loss"},
+		{tLossGrad, "LossGrad"},
+		{tOptimizer, "Optimizer"},
+		{tOptimizerCheckpoint, "OptimizerCheckpoint"},
+		{tFFT, "FFT"},
+		{tRFFT, "RFFT"},
+		{tFFTConvolve, "FFTConvolve"},
+		{tSparse, "Sparse"},
+		{tSparseMatMul, "SparseMatMul"},
+		{tSparseOps, "SparseOps"},
+		{tSparseMapApply, "SparseMapApply"},
+		{tMemoryPool, "MemoryPool"},
+		{tArena, "Arena"},
+		{tShare, "Share"},
+		{tClone, "Clone"},
+		{tCopyFrom, "CopyFrom"},
+		{tSetPoint, "SetPoint"},
+		{tSetRegion, "SetRegion"},
 	})
 
 	if err := g.Validate(); err != nil {
@@ -0,0 +1,377 @@
+package tensors
+
+import "math"
+
+// Tape records the operations performed on Variables, so that gradients can be computed in
+// reverse by Variable.Backward. Every Variable belongs to a single Tape, and operations may only
+// combine Variables from the same Tape.
+//
+// A Tape is not safe for concurrent use. Variables should be recorded onto a new Tape for each
+// pass; Tape.ZeroGrad may be used to reset gradients if a Tape is reused.
+type Tape struct {
+	vars []*Variable
+}
+
+// Variable wraps a Tensor, recording the operations that produce it so that the gradient of some
+// final result with respect to it can be computed. Variables are created with Tape.Var, and from
+// operations on other Variables.
+type Variable struct {
+	// Value is the value of the Variable. It should not be modified after creation, as it may be
+	// needed to compute gradients.
+	Value Tensor
+
+	// Grad is the accumulated gradient of every call to Backward with respect to this Variable.
+	// It is a zero-valued Tensor until the first call to Backward that reaches the Variable, and
+	// afterwards has the same dimensions as Value.
+	Grad Tensor
+
+	tape *Tape
+	id   int
+
+	parents []*Variable
+
+	// backward gives the gradient with respect to each of the parents, given the gradient with
+	// respect to this Variable. It is nil for Variables created with Tape.Var.
+	backward func(grad Tensor) []Tensor
+}
+
+// NewTape returns a new, empty Tape.
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+// Var records a new Variable with the given value onto the Tape. The Tensor is not copied.
+func (tp *Tape) Var(t Tensor) *Variable {
+	return tp.record(t, nil, nil)
+}
+
+// record adds a new Variable to the Tape.
+func (tp *Tape) record(value Tensor, parents []*Variable, backward func(Tensor) []Tensor) *Variable {
+	v := &Variable{
+		Value:    value,
+		tape:     tp,
+		id:       len(tp.vars),
+		parents:  parents,
+		backward: backward,
+	}
+
+	tp.vars = append(tp.vars, v)
+	return v
+}
+
+// ZeroGrad resets the gradient of every Variable on the Tape to zero.
+func (tp *Tape) ZeroGrad() {
+	for _, v := range tp.vars {
+		v.Grad = Tensor{}
+	}
+}
+
+// Backward computes the gradient of the Variable with respect to every Variable on the Tape that it
+// depends upon, adding them to the Grad of each. The Variable must have exactly one value, as is
+// the case with the results of Sum and Mean.
+//
+// Backward will panic with ErrNotScalar if the Variable has more than one value. BackwardSafe
+// returns the error instead.
+func (v *Variable) Backward() {
+	if err := v.BackwardSafe(); err != nil {
+		panic(err)
+	}
+}
+
+// BackwardSafe undergoes the same process as Backward, but returns error instead of panicking.
+func (v *Variable) BackwardSafe() error {
+	if v.Value.Size() != 1 {
+		return ErrNotScalar
+	}
+
+	// Ones does not accept the dimensions of a rank-0 Variable
+	if len(v.Value.Dims) == 0 {
+		return v.BackwardWithSafe(Scalar(1))
+	}
+
+	return v.BackwardWithSafe(Ones(v.Value.Dims))
+}
+
+// BackwardWith is the same as Backward, except that the gradient with respect to the Variable is
+// given, instead of being 1. This allows Backward to start from Variables with more than one
+// value.
+//
+// BackwardWith will panic with a ShapeMismatchError if grad does not have the same dimensions as
+// the Variable, or ErrLayoutMismatch if it has a different Layout. BackwardWithSafe returns the
+// error instead.
+func (v *Variable) BackwardWith(grad Tensor) {
+	if err := v.BackwardWithSafe(grad); err != nil {
+		panic(err)
+	}
+}
+
+// BackwardWithSafe undergoes the same process as BackwardWith, but returns error instead of
+// panicking.
+func (v *Variable) BackwardWithSafe(grad Tensor) error {
+	if err := checkEquals(v.Value.Interpreter, grad.Interpreter, 0); err != nil {
+		return err
+	}
+
+	// the gradients for only this pass. Each is added to the Variable's Grad once it is complete,
+	// which is guaranteed because every Variable comes after its parents on the Tape.
+	grads := make([]Tensor, v.id+1)
+	grads[v.id] = grad
+
+	for i := v.id; i >= 0; i-- {
+		g := grads[i]
+		if g.Values == nil {
+			continue
+		}
+
+		cur := v.tape.vars[i]
+		if cur.Grad.Values == nil {
+			cur.Grad = NewTensorExtended(cur.Value.Dims)
+		}
+
+		for j, x := range g.Values {
+			cur.Grad.Values[j] += x
+		}
+
+		if cur.backward == nil {
+			continue
+		}
+
+		for j, pg := range cur.backward(g) {
+			p := cur.parents[j]
+			if grads[p.id].Values == nil {
+				grads[p.id] = pg
+			} else {
+				grads[p.id] = Add(grads[p.id], pg)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkTape returns ErrTapeMismatch if u is not from the same Tape as v.
+func (v *Variable) checkTape(u *Variable) error {
+	if v.tape != u.tape {
+		return ErrTapeMismatch
+	}
+
+	return nil
+}
+
+// unbroadcast sums the gradient g along any axes that were broadcast from the given Interpreter,
+// giving the gradient with respect to the original operand.
+func unbroadcast(g Tensor, in Interpreter) Tensor {
+	if Equals(g.Interpreter, in) {
+		return g
+	}
+
+	res := NewTensorExtended(in.Dims)
+	broadcastEach(g.Interpreter, []Interpreter{in}, func(i int, idx []int) {
+		res.Values[idx[0]] += g.Values[i]
+	})
+
+	return res
+}
+
+// binary is the shared implementation of the element-wise operations between Variables. fn
+// computes the value, and grads computes the gradients with respect to v and u, at the dimensions
+// of the result; they are then summed along any broadcast axes.
+func (v *Variable) binary(u *Variable, fn func(a, b Tensor) (Tensor, error),
+	grads func(g Tensor) (Tensor, Tensor)) (*Variable, error) {
+
+	if err := v.checkTape(u); err != nil {
+		return nil, err
+	}
+
+	value, err := fn(v.Value, u.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.tape.record(value, []*Variable{v, u}, func(g Tensor) []Tensor {
+		gv, gu := grads(g)
+		return []Tensor{unbroadcast(gv, v.Value.Interpreter), unbroadcast(gu, u.Value.Interpreter)}
+	}), nil
+}
+
+// unary is the shared implementation of the element-wise functions of a single Variable. deriv
+// gives the derivative at each point, given the input x and the output y.
+func (v *Variable) unary(fn func(x float64) float64, deriv func(x, y float64) float64) *Variable {
+	value := v.Value.Apply(fn)
+	return v.tape.record(value, []*Variable{v}, func(g Tensor) []Tensor {
+		res := NewTensorExtended(g.Dims)
+		for i := range res.Values {
+			res.Values[i] = g.Values[i] * deriv(v.Value.Values[i], value.Values[i])
+		}
+
+		return []Tensor{res}
+	})
+}
+
+// mustVar panics if err is not nil, and otherwise returns v.
+func mustVar(v *Variable, err error) *Variable {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// Add returns a new Variable equal to the element-wise sum of v and u, broadcasting them together
+// as with the function Add. Add will panic with ErrTapeMismatch if the Variables are from
+// different Tapes, or a ShapeMismatchError if they cannot be broadcast. AddSafe returns these
+// errors instead.
+func (v *Variable) Add(u *Variable) *Variable { return mustVar(v.AddSafe(u)) }
+
+// AddSafe undergoes the same process as Add, but returns error instead of panicking.
+func (v *Variable) AddSafe(u *Variable) (*Variable, error) {
+	return v.binary(u, AddSafe, func(g Tensor) (Tensor, Tensor) {
+		return g, g
+	})
+}
+
+// Sub returns a new Variable equal to the element-wise difference v - u. It otherwise behaves as
+// Add does.
+func (v *Variable) Sub(u *Variable) *Variable { return mustVar(v.SubSafe(u)) }
+
+// SubSafe undergoes the same process as Sub, but returns error instead of panicking.
+func (v *Variable) SubSafe(u *Variable) (*Variable, error) {
+	return v.binary(u, SubSafe, func(g Tensor) (Tensor, Tensor) {
+		return g, g.Scale(-1)
+	})
+}
+
+// Mul returns a new Variable equal to the element-wise product of v and u. It otherwise behaves as
+// Add does.
+func (v *Variable) Mul(u *Variable) *Variable { return mustVar(v.MulSafe(u)) }
+
+// MulSafe undergoes the same process as Mul, but returns error instead of panicking.
+func (v *Variable) MulSafe(u *Variable) (*Variable, error) {
+	return v.binary(u, MulSafe, func(g Tensor) (Tensor, Tensor) {
+		return Mul(g, u.Value), Mul(g, v.Value)
+	})
+}
+
+// Div returns a new Variable equal to the element-wise quotient v / u. It otherwise behaves as Add
+// does.
+func (v *Variable) Div(u *Variable) *Variable { return mustVar(v.DivSafe(u)) }
+
+// DivSafe undergoes the same process as Div, but returns error instead of panicking.
+func (v *Variable) DivSafe(u *Variable) (*Variable, error) {
+	return v.binary(u, DivSafe, func(g Tensor) (Tensor, Tensor) {
+		gu := Div(Mul(g, v.Value), Mul(u.Value, u.Value))
+		return Div(g, u.Value), gu.Scale(-1)
+	})
+}
+
+// MatMul returns a new Variable equal to the matrix product of v and u, as with the function
+// MatMul. MatMul will panic with ErrTapeMismatch if the Variables are from different Tapes, or any
+// of the errors from MatMulSafe. MatMulSafe returns these errors instead.
+func (v *Variable) MatMul(u *Variable) *Variable { return mustVar(v.MatMulSafe(u)) }
+
+// MatMulSafe undergoes the same process as MatMul, but returns error instead of panicking.
+func (v *Variable) MatMulSafe(u *Variable) (*Variable, error) {
+	if err := v.checkTape(u); err != nil {
+		return nil, err
+	}
+
+	value, err := MatMulSafe(v.Value, u.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.tape.record(value, []*Variable{v, u}, func(g Tensor) []Tensor {
+		return []Tensor{MatMul(g, u.Value.Transpose()), MatMul(v.Value.Transpose(), g)}
+	}), nil
+}
+
+// Neg returns a new Variable with every value of v negated.
+func (v *Variable) Neg() *Variable {
+	return v.Scale(-1)
+}
+
+// Scale returns a new Variable with every value of v multiplied by c.
+func (v *Variable) Scale(c float64) *Variable {
+	return v.tape.record(v.Value.Scale(c), []*Variable{v}, func(g Tensor) []Tensor {
+		return []Tensor{g.Scale(c)}
+	})
+}
+
+// Sum returns a new Variable with Dims [1], equal to the sum of every value of v.
+func (v *Variable) Sum() *Variable {
+	value := Full([]int{1}, v.Value.Sum())
+	return v.tape.record(value, []*Variable{v}, func(g Tensor) []Tensor {
+		res := NewTensorExtended(v.Value.Dims)
+		for i := range res.Values {
+			res.Values[i] = g.Values[0]
+		}
+
+		return []Tensor{res}
+	})
+}
+
+// Mean returns a new Variable with Dims [1], equal to the mean of every value of v.
+func (v *Variable) Mean() *Variable {
+	return v.Sum().Scale(1 / float64(v.Value.Size()))
+}
+
+// SumAxis returns a new Variable equal to v summed along the given axis, as with Tensor.SumAxis.
+// SumAxis will panic with an AxisError if axis is out of bounds; SumAxisSafe returns the error
+// instead.
+func (v *Variable) SumAxis(axis int) *Variable { return mustVar(v.SumAxisSafe(axis)) }
+
+// SumAxisSafe undergoes the same process as SumAxis, but returns error instead of panicking.
+func (v *Variable) SumAxisSafe(axis int) (*Variable, error) {
+	value, err := v.Value.SumAxisSafe(axis)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.tape.record(value, []*Variable{v}, func(g Tensor) []Tensor {
+		// the gradient is the same for every value that was summed
+		return []Tensor{Add(NewTensorExtended(v.Value.Dims), g)}
+	}), nil
+}
+
+// Reshape returns a new Variable with the values of v, but with the given dimensions, as with
+// Tensor.Reshape. Reshape will panic with the errors from Tensor.ReshapeSafe; ReshapeSafe returns
+// them instead.
+func (v *Variable) Reshape(dims []int) *Variable { return mustVar(v.ReshapeSafe(dims)) }
+
+// ReshapeSafe undergoes the same process as Reshape, but returns error instead of panicking.
+func (v *Variable) ReshapeSafe(dims []int) (*Variable, error) {
+	value, err := v.Value.ReshapeSafe(dims)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.tape.record(value, []*Variable{v}, func(g Tensor) []Tensor {
+		return []Tensor{{Interpreter: v.Value.Interpreter, Values: g.Values}}
+	}), nil
+}
+
+// Exp returns a new Variable equal to e raised to each value of v.
+func (v *Variable) Exp() *Variable {
+	return v.unary(math.Exp, func(x, y float64) float64 { return y })
+}
+
+// Log returns a new Variable equal to the natural logarithm of each value of v.
+func (v *Variable) Log() *Variable {
+	return v.unary(math.Log, func(x, y float64) float64 { return 1 / x })
+}
+
+// Sigmoid returns a new Variable equal to the logistic function of each value of v.
+func (v *Variable) Sigmoid() *Variable {
+	return v.unary(sigmoid, func(x, y float64) float64 { return y * (1 - y) })
+}
+
+// Tanh returns a new Variable equal to the hyperbolic tangent of each value of v.
+func (v *Variable) Tanh() *Variable {
+	return v.unary(math.Tanh, func(x, y float64) float64 { return 1 - y*y })
+}
+
+// ReLU returns a new Variable equal to the maximum of zero and each value of v. The derivative at
+// zero is taken to be zero.
+func (v *Variable) ReLU() *Variable {
+	return v.unary(relu, func(x, y float64) float64 { return boolValue(x > 0) })
+}
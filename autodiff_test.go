@@ -0,0 +1,134 @@
+package tensors
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// requires Arithmetic, Reshape, MatMul, Random
+func tAutodiff(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	table := []struct {
+		name   string
+		inputs [][]int
+		fn     func(vs []*Variable) *Variable
+	}{
+		{"Add", [][]int{{3, 2}, {3}}, func(vs []*Variable) *Variable { return vs[0].Add(vs[1]).Mul(vs[0]).Sum() }},
+		{"Sub", [][]int{{3, 2}, {1, 2}}, func(vs []*Variable) *Variable { return vs[0].Sub(vs[1]).Mul(vs[1]).Sum() }},
+		{"Div", [][]int{{4}, {4}}, func(vs []*Variable) *Variable { return vs[0].Div(vs[1].Exp()).Sum() }},
+		{"MatMul", [][]int{{3, 2}, {4, 3}}, func(vs []*Variable) *Variable { return vs[0].MatMul(vs[1]).Tanh().Sum() }},
+		{"Mean", [][]int{{2, 5}}, func(vs []*Variable) *Variable { return vs[0].Mul(vs[0]).Mean() }},
+		{"SumAxis", [][]int{{3, 4}}, func(vs []*Variable) *Variable { return vs[0].SumAxis(1).Sigmoid().Sum() }},
+		{"Reshape", [][]int{{6}, {2, 3}}, func(vs []*Variable) *Variable { return vs[0].Reshape([]int{2, 3}).Mul(vs[1]).Sum() }},
+		{"Activations", [][]int{{5}}, func(vs []*Variable) *Variable {
+			return vs[0].ReLU().Add(vs[0].Sigmoid()).Add(vs[0].Exp().Log().Neg()).Mul(vs[0]).Sum()
+		}},
+	}
+
+	const eps = 1e-6
+
+	for _, tab := range table {
+		ts := make([]Tensor, len(tab.inputs))
+		for i, dims := range tab.inputs {
+			ts[i] = NewRandom(dims, Uniform(0.5, 2), rng)
+		}
+
+		eval := func() float64 {
+			tape := NewTape()
+			vs := make([]*Variable, len(ts))
+			for i := range ts {
+				vs[i] = tape.Var(ts[i])
+			}
+
+			return tab.fn(vs).Value.Values[0]
+		}
+
+		tape := NewTape()
+		vs := make([]*Variable, len(ts))
+		for i := range ts {
+			vs[i] = tape.Var(ts[i])
+		}
+
+		if err := tab.fn(vs).BackwardSafe(); err != nil {
+			t.Errorf("%s: Backward returned error: %q.", tab.name, err)
+			continue
+		}
+
+		// compare against central differences
+		for i, x := range ts {
+			for j := range x.Values {
+				orig := x.Values[j]
+				x.Values[j] = orig + eps
+				plus := eval()
+				x.Values[j] = orig - eps
+				minus := eval()
+				x.Values[j] = orig
+
+				numeric := (plus - minus) / (2 * eps)
+				if math.Abs(numeric-vs[i].Grad.Values[j]) > 1e-5 {
+					t.Errorf("%s: Bad gradient for input %d at index %d. Expected %v, Got %v.",
+						tab.name, i, j, numeric, vs[i].Grad.Values[j])
+				}
+			}
+		}
+	}
+}
+
+// requires Autodiff
+func tBackward(t *testing.T) {
+	tape := NewTape()
+	x := tape.Var(FromNested([]float64{1, 2, 3}))
+	y := x.Mul(x)
+
+	// gradients accumulate between calls to Backward, but not from earlier passes
+	y.Sum().Backward()
+	y.Sum().Backward()
+	handleReturn(t, "Backward", FromNested([]float64{4, 8, 12}), x.Grad, "")
+
+	tape.ZeroGrad()
+	if x.Grad.Values != nil {
+		t.Errorf("ZeroGrad: Grad was not reset. Got %v.", x.Grad)
+	}
+
+	// only Variables that the result depends upon are given gradients
+	unused := tape.Var(Ones([]int{2}))
+	_ = unused.Scale(2)
+	x.Sum().Backward()
+	if unused.Grad.Values != nil {
+		t.Errorf("Backward: Unused Variable was given a gradient. Got %v.", unused.Grad)
+	}
+
+	err := y.BackwardWithSafe(FromNested([]float64{1, 0, -1}))
+	_ = handleErrors(t, "BackwardWith", nil, err, "") &&
+		handleReturn(t, "BackwardWith", FromNested([]float64{3, 1, -5}), x.Grad, "")
+
+	handleErrors(t, "Backward", ErrNotScalar, y.BackwardSafe(), "")
+
+	s := NewTape().Var(Scalar(3))
+	err = s.Mul(s).BackwardSafe()
+	_ = handleErrors(t, "Backward", nil, err, "Rank 0.") &&
+		handleReturn(t, "Backward", Scalar(6), s.Grad, "Rank 0.")
+	r := NewTape().Var(Scalar(0))
+	err = r.Exp().Sum().BackwardSafe()
+	_ = handleErrors(t, "Backward", nil, err, "Rank 0 Exp.") &&
+		handleReturn(t, "Backward", Scalar(1), r.Grad, "Rank 0 Exp.")
+	handleErrors(t, "BackwardWith", ShapeMismatchError{}, y.BackwardWithSafe(Ones([]int{2})), "")
+
+	// zero-size inputs receive empty gradients
+	e := NewTape().Var(NewTensorExtended([]int{0, 3}))
+	err = e.SumAxis(0).Sum().BackwardSafe()
+	_ = handleErrors(t, "Backward", nil, err, "Empty SumAxis.") &&
+		handleReturn(t, "Backward", NewTensorExtended([]int{0, 3}), e.Grad, "Empty SumAxis.")
+	f := NewTape().Var(NewTensorExtended([]int{2, 0}))
+	err = f.Mul(f).Sum().BackwardSafe()
+	_ = handleErrors(t, "Backward", nil, err, "Empty Mul.") &&
+		handleReturn(t, "Backward", NewTensorExtended([]int{2, 0}), f.Grad, "Empty Mul.")
+
+	other := NewTape().Var(Ones([]int{3}))
+	_, err = x.AddSafe(other)
+	handleErrors(t, "Add", ErrTapeMismatch, err, "")
+	_, err = x.MatMulSafe(x)
+	handleErrors(t, "MatMul", LengthMismatchError{}, err, "")
+}
@@ -0,0 +1,64 @@
+package tensors
+
+// broadcastDims returns the dimensions resulting from broadcasting the given Interpreters
+// together. Dimensions are aligned starting from Dims[0]; Interpreters with fewer dimensions are
+// treated as having size 1 along the missing higher dimensions. Along each axis, every size must
+// either be equal or 1, in which case the single value is repeated.
+//
+// If the dimensions are incompatible, a ShapeMismatchError is returned naming the first offending
+// Interpreter and axis.
+func broadcastDims(ins ...Interpreter) ([]int, error) {
+	var dims []int
+	for _, in := range ins {
+		if len(in.Dims) > len(dims) {
+			dims = make([]int, len(in.Dims))
+		}
+	}
+
+	for i := range dims {
+		dims[i] = 1
+	}
+
+	for n, in := range ins {
+		for i, d := range in.Dims {
+			if d == dims[i] || d == 1 {
+				continue
+			} else if dims[i] != 1 {
+				return nil, ShapeMismatchError{n, i, in.Dims, dims}
+			}
+
+			dims[i] = d
+		}
+	}
+
+	return dims, nil
+}
+
+// broadcastEach calls fn for every index of out, along with the corresponding index in each of
+// ins. Each of ins must be broadcastable to out, as given by broadcastDims.
+func broadcastEach(out Interpreter, ins []Interpreter, fn func(i int, indices []int)) {
+	// strides of each input, set to zero for any broadcast axes so that they don't move
+	strides := make([][]int, len(ins))
+	for n, in := range ins {
+		strides[n] = make([]int, len(out.Dims))
+		for i, d := range in.Dims {
+			if d != 1 {
+				strides[n][i] = in.stride(i)
+			}
+		}
+	}
+
+	point := make([]int, len(out.Dims))
+	indices := make([]int, len(ins))
+	for i := 0; i < out.Size(); i++ {
+		for n := range ins {
+			indices[n] = 0
+			for a, p := range point {
+				indices[n] += p * strides[n][a]
+			}
+		}
+
+		fn(i, indices)
+		out.IncrementFast(point)
+	}
+}
@@ -0,0 +1,79 @@
+package tensors
+
+import (
+	"math/cmplx"
+)
+
+// ComplexTensor is the complex analog to Tensor, returned by the Fourier transforms. Its values
+// are stored in the same order as those of a Tensor.
+type ComplexTensor struct {
+	Interpreter
+
+	// Values is the set of values stored in the ComplexTensor. As with Tensor, the description
+	// for the storage of these values can be found in the documentation for Interpreter.Dims
+	Values []complex128
+}
+
+// NewComplexTensor returns a new ComplexTensor with the given dimensions, with all values zero.
+// NewComplexTensor will panic if given invalid dimensions. NewComplexTensorSafe returns the error
+// from NewInterpreterSafe instead.
+func NewComplexTensor(dims []int) ComplexTensor {
+	c, err := NewComplexTensorSafe(dims)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// NewComplexTensorSafe undergoes the same process as NewComplexTensor, but returns error instead
+// of panicking.
+func NewComplexTensorSafe(dims []int) (ComplexTensor, error) {
+	in, err := NewInterpreterSafe(dims)
+	if err != nil {
+		return ComplexTensor{}, err
+	}
+
+	return ComplexTensor{in, make([]complex128, in.Size())}, nil
+}
+
+// Complex returns a ComplexTensor with the same dimensions as t, whose real parts are the values
+// of t.
+func (t Tensor) Complex() ComplexTensor {
+	c := ComplexTensor{t.Interpreter, make([]complex128, len(t.Values))}
+	for i, v := range t.Values {
+		c.Values[i] = complex(v, 0)
+	}
+
+	return c
+}
+
+// apply returns a new Tensor with the same dimensions as c, with fn applied to each value.
+func (c ComplexTensor) apply(fn func(complex128) float64) Tensor {
+	t := Tensor{Interpreter: c.Interpreter, Values: make([]float64, len(c.Values))}
+	for i, v := range c.Values {
+		t.Values[i] = fn(v)
+	}
+
+	return t
+}
+
+// Real returns the real parts of the values of c.
+func (c ComplexTensor) Real() Tensor {
+	return c.apply(func(v complex128) float64 { return real(v) })
+}
+
+// Imag returns the imaginary parts of the values of c.
+func (c ComplexTensor) Imag() Tensor {
+	return c.apply(func(v complex128) float64 { return imag(v) })
+}
+
+// Abs returns the magnitudes of the values of c.
+func (c ComplexTensor) Abs() Tensor {
+	return c.apply(cmplx.Abs)
+}
+
+// Phase returns the phases (arguments) of the values of c, in the range [-π, π].
+func (c ComplexTensor) Phase() Tensor {
+	return c.apply(cmplx.Phase)
+}
@@ -0,0 +1,249 @@
+package tensors
+
+// checkShapes checks that the dimensions of every Tensor are equal to those of the first, except
+// along the given axis. If skip is -1, all dimensions are checked. A ShapeMismatchError naming the
+// first offending Tensor is returned if they do not match.
+func checkShapes(skip int, ts []Tensor) error {
+	base := ts[0].Dims
+	for n, t := range ts[1:] {
+		if len(t.Dims) != len(base) {
+			return ShapeMismatchError{n + 1, -1, t.Dims, base}
+		}
+
+		for i := range base {
+			if i != skip && t.Dims[i] != base[i] {
+				return ShapeMismatchError{n + 1, i, t.Dims, base}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Concat joins the given Tensors together along an existing axis, returning a new Tensor. The
+// dimensions of every Tensor must be equal, except along the given axis, where the dimension of
+// the result is the sum of all of them. For example, concatenating Tensors with Dims [2, 3] and
+// [2, 5] along axis 1 gives a Tensor with Dims [2, 8].
+//
+// Concat will panic if any of the following conditions are met:
+//		(0) If no Tensors are given. This will cause ErrNoTensors.
+//		(1) If axis is not a dimension of the first Tensor. This will cause an AxisError.
+//		(2) If any of the Tensors have different dimensions than the first, other than along axis.
+//			This will cause a ShapeMismatchError.
+// Each is wrapped in an OpError. ConcatSafe returns these errors instead.
+func Concat(axis int, ts ...Tensor) Tensor {
+	t, err := ConcatSafe(axis, ts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// ConcatSafe undergoes the same process as Concat, but returns error instead of panicking.
+func ConcatSafe(axis int, ts ...Tensor) (Tensor, error) {
+	if len(ts) == 0 {
+		return Tensor{}, opError("Concat", ErrNoTensors)
+	} else if err := checkLayout(interpreters(ts)...); err != nil {
+		return Tensor{}, opError("Concat", err, interpreters(ts)...)
+	} else if err := ts[0].checkAxis(axis); err != nil {
+		return Tensor{}, opError("Concat", err, interpreters(ts)...)
+	} else if err := checkShapes(axis, ts); err != nil {
+		return Tensor{}, opError("Concat", err, interpreters(ts)...)
+	}
+
+	return concat(axis, ts), nil
+}
+
+// interpreters returns the Interpreter of each of the Tensors, for use with opError.
+func interpreters(ts []Tensor) []Interpreter {
+	ins := make([]Interpreter, len(ts))
+	for i, t := range ts {
+		ins[i] = t.Interpreter
+	}
+
+	return ins
+}
+
+// concat is the shared implementation of Concat and Stack, once the Tensors have been checked.
+func concat(axis int, ts []Tensor) Tensor {
+	dims := make([]int, len(ts[0].Dims))
+	copy(dims, ts[0].Dims)
+	for _, t := range ts[1:] {
+		dims[axis] += t.Dims[axis]
+	}
+
+	result := NewTensor(dims)
+	outer, inner := result.split(axis)
+
+	// Each outer block of every operand is contiguous, and the outer blocks of the result are
+	// made up of the corresponding blocks from each operand, in order.
+	index := 0
+	for o := 0; o < outer; o++ {
+		for _, t := range ts {
+			run := t.Dims[axis] * inner
+			index += copy(result.Values[index:], t.Values[o*run:(o+1)*run])
+		}
+	}
+
+	return result
+}
+
+// Stack joins the given Tensors together along a new axis, returning a new Tensor. Every Tensor
+// must have the same dimensions. The new axis is inserted at the given position, and its size is
+// the number of Tensors given. For example, stacking 5 Tensors with Dims [2, 3] along axis 2
+// gives a Tensor with Dims [2, 3, 5].
+//
+// Axis may be equal to the number of dimensions of the Tensors, in which case the new axis is the
+// highest dimension. This is the natural way to build minibatches, as each Tensor is then
+// contiguous in the result.
+//
+// Stack will panic under the same conditions as Concat. StackSafe returns error instead.
+func Stack(axis int, ts ...Tensor) Tensor {
+	t, err := StackSafe(axis, ts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// StackSafe undergoes the same process as Stack, but returns error instead of panicking.
+func StackSafe(axis int, ts ...Tensor) (Tensor, error) {
+	if len(ts) == 0 {
+		return Tensor{}, opError("Stack", ErrNoTensors)
+	} else if err := checkLayout(interpreters(ts)...); err != nil {
+		return Tensor{}, opError("Stack", err, interpreters(ts)...)
+	} else if axis < 0 || axis > len(ts[0].Dims) {
+		return Tensor{}, opError("Stack", AxisError{axis, len(ts[0].Dims) + 1}, interpreters(ts)...)
+	} else if err := checkShapes(-1, ts); err != nil {
+		return Tensor{}, opError("Stack", err, interpreters(ts)...)
+	}
+
+	// Inserting a dimension of size 1 doesn't change the order of values, so we can view each
+	// Tensor with the new axis and concatenate along it.
+	dims := make([]int, len(ts[0].Dims)+1)
+	copy(dims, ts[0].Dims[:axis])
+	dims[axis] = 1
+	copy(dims[axis+1:], ts[0].Dims[axis:])
+
+	in := NewInterpreter(dims)
+	views := make([]Tensor, len(ts))
+	for i, t := range ts {
+		views[i] = Tensor{Interpreter: in, Values: t.Values}
+	}
+
+	return concat(axis, views), nil
+}
+
+// Split divides the Tensor along the given axis into new Tensors with the given sizes along that
+// axis. It is the inverse of Concat. The values of the returned Tensors are copies, and do not
+// share memory with the original.
+//
+// Split will panic with an AxisError if axis is not a dimension of the Tensor, a DimsValueError if
+// any of the sizes are ≤ 0, or ErrSplitSizes if the sizes do not sum to Dims[axis]. SplitSafe
+// returns these errors instead.
+func (t Tensor) Split(axis int, sizes []int) []Tensor {
+	ts, err := t.SplitSafe(axis, sizes)
+	if err != nil {
+		panic(err)
+	}
+
+	return ts
+}
+
+// SplitSafe undergoes the same process as Split, but returns error instead of panicking.
+func (t Tensor) SplitSafe(axis int, sizes []int) ([]Tensor, error) {
+	if err := checkLayout(t.Interpreter); err != nil {
+		return nil, opError("Split", err, t.Interpreter)
+	} else if err := t.checkAxis(axis); err != nil {
+		return nil, opError("Split", err, t.Interpreter)
+	}
+
+	sum := 0
+	for i, s := range sizes {
+		if s <= 0 {
+			return nil, opError("Split", DimsValueError{sizes, i}, t.Interpreter)
+		}
+
+		sum += s
+	}
+
+	if sum != t.Dims[axis] {
+		return nil, opError("Split", ErrSplitSizes, t.Interpreter)
+	}
+
+	outer, inner := t.split(axis)
+
+	ts := make([]Tensor, len(sizes))
+	for i, s := range sizes {
+		dims := make([]int, len(t.Dims))
+		copy(dims, t.Dims)
+		dims[axis] = s
+
+		ts[i] = NewTensor(dims)
+	}
+
+	// the mirror image of Concat
+	index := 0
+	for o := 0; o < outer; o++ {
+		for i, s := range sizes {
+			run := s * inner
+			index += copy(ts[i].Values[o*run:(o+1)*run], t.Values[index:])
+		}
+	}
+
+	return ts, nil
+}
+
+// Chunk divides the Tensor along the given axis into n new Tensors of as equal size as possible.
+// Each Tensor has size ceil(Dims[axis] / n) along the axis, except for the last, which may be
+// smaller. If that would result in fewer than n Tensors, the sizes are instead balanced so that
+// exactly n are returned.
+//
+// Chunk will panic with an AxisError if axis is not a dimension of the Tensor, or ErrChunkCount
+// if n is not in the range [1, Dims[axis]]. ChunkSafe returns these errors instead.
+func (t Tensor) Chunk(axis, n int) []Tensor {
+	ts, err := t.ChunkSafe(axis, n)
+	if err != nil {
+		panic(err)
+	}
+
+	return ts
+}
+
+// ChunkSafe undergoes the same process as Chunk, but returns error instead of panicking.
+func (t Tensor) ChunkSafe(axis, n int) ([]Tensor, error) {
+	if err := t.checkAxis(axis); err != nil {
+		return nil, opError("Chunk", err, t.Interpreter)
+	} else if n < 1 || n > t.Dims[axis] {
+		return nil, opError("Chunk", ErrChunkCount, t.Interpreter)
+	}
+
+	d := t.Dims[axis]
+	size := (d + n - 1) / n
+
+	var sizes []int
+	if (d+size-1)/size == n {
+		for rem := d; rem > 0; rem -= size {
+			if rem < size {
+				sizes = append(sizes, rem)
+			} else {
+				sizes = append(sizes, size)
+			}
+		}
+	} else {
+		// eg. splitting 6 into 4 with size 2 would only give 3 chunks. Instead, we give the first
+		// (d % n) chunks one extra.
+		for i := 0; i < n; i++ {
+			if i < d%n {
+				sizes = append(sizes, d/n+1)
+			} else {
+				sizes = append(sizes, d/n)
+			}
+		}
+	}
+
+	ts, err := t.SplitSafe(axis, sizes)
+	return ts, opError("Chunk", err, t.Interpreter)
+}
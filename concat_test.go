@@ -0,0 +1,129 @@
+package tensors
+
+import (
+	"errors"
+	"testing"
+)
+
+// requires FromNested, Constructors
+func tConcat(t *testing.T) {
+	a := FromNested([][]float64{{1, 2}, {3, 4}})
+	b := FromNested([][]float64{{5, 6}})
+	c := FromNested([][]float64{{7}, {8}})
+
+	table := []struct {
+		axis int
+		ts   []Tensor
+		res  Tensor
+		err  error
+	}{
+		{1, []Tensor{a, b}, FromNested([][]float64{{1, 2}, {3, 4}, {5, 6}}), nil},
+		{0, []Tensor{a, c}, FromNested([][]float64{{1, 2, 7}, {3, 4, 8}}), nil},
+		{0, []Tensor{c, a, c}, FromNested([][]float64{{7, 1, 2, 7}, {8, 3, 4, 8}}), nil},
+		{2, []Tensor{a}, Tensor{}, AxisError{}},
+		{-1, []Tensor{a}, Tensor{}, AxisError{}},
+		{0, nil, Tensor{}, ErrNoTensors},
+		{0, []Tensor{a, b}, Tensor{}, ShapeMismatchError{}},
+		{1, []Tensor{a, Ones([]int{2})}, Tensor{}, ShapeMismatchError{}},
+	}
+
+	for _, tab := range table {
+		res, err := ConcatSafe(tab.axis, tab.ts...)
+
+		_ = handleErrors(t, "Concat", tab.err, err, "Axis: %d, Tensors: %v.", tab.axis, tab.ts) &&
+			handleReturn(t, "Concat", tab.res, res, "Axis: %d, Tensors: %v.", tab.axis, tab.ts)
+	}
+
+	if _, err := ConcatSafe(0, a, a, b); err != nil {
+		var e ShapeMismatchError
+		if !errors.As(err, &e) || e.Operand() != 2 || e.Axis() != 1 {
+			t.Errorf("Concat: Error did not name the offending operand and axis. Got %q.", err)
+		}
+	}
+}
+
+// requires Concat
+func tStack(t *testing.T) {
+	a := FromNested([]float64{1, 2})
+	b := FromNested([]float64{3, 4})
+
+	table := []struct {
+		axis int
+		ts   []Tensor
+		res  Tensor
+		err  error
+	}{
+		{1, []Tensor{a, b}, FromNested([][]float64{{1, 2}, {3, 4}}), nil},
+		{0, []Tensor{a, b}, FromNested([][]float64{{1, 3}, {2, 4}}), nil},
+		{2, []Tensor{a, b}, Tensor{}, AxisError{}},
+		{0, []Tensor{a, Ones([]int{3})}, Tensor{}, ShapeMismatchError{}},
+		{0, nil, Tensor{}, ErrNoTensors},
+	}
+
+	for _, tab := range table {
+		res, err := StackSafe(tab.axis, tab.ts...)
+
+		_ = handleErrors(t, "Stack", tab.err, err, "Axis: %d, Tensors: %v.", tab.axis, tab.ts) &&
+			handleReturn(t, "Stack", tab.res, res, "Axis: %d, Tensors: %v.", tab.axis, tab.ts)
+	}
+}
+
+// requires Concat
+func tSplit(t *testing.T) {
+	tensor := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10, 11, 12}})
+
+	table := []struct {
+		axis  int
+		sizes []int
+		res   []Tensor
+		err   error
+	}{
+		{0, []int{1, 2}, []Tensor{
+			FromNested([][]float64{{1}, {4}, {7}, {10}}),
+			FromNested([][]float64{{2, 3}, {5, 6}, {8, 9}, {11, 12}}),
+		}, nil},
+		{1, []int{3, 1}, []Tensor{
+			FromNested([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}),
+			FromNested([][]float64{{10, 11, 12}}),
+		}, nil},
+		{2, []int{3}, nil, AxisError{}},
+		{0, []int{1, 1}, nil, ErrSplitSizes},
+		{0, []int{3, 0}, nil, DimsValueError{}},
+	}
+
+	for _, tab := range table {
+		res, err := tensor.SplitSafe(tab.axis, tab.sizes)
+
+		if handleErrors(t, "Split", tab.err, err, "Axis: %d, Sizes: %v.", tab.axis, tab.sizes) &&
+			handleReturn(t, "Split", tab.res, res, "Axis: %d, Sizes: %v.", tab.axis, tab.sizes) {
+			handleReturn(t, "Split", tensor, Concat(tab.axis, res...), "Concat did not invert Split.")
+		}
+	}
+
+	chunkTable := []struct {
+		axis, n int
+		sizes   []int
+		err     error
+	}{
+		{1, 2, []int{2, 2}, nil},
+		{1, 3, []int{2, 1, 1}, nil},
+		{1, 4, []int{1, 1, 1, 1}, nil},
+		{0, 2, []int{2, 1}, nil},
+		{1, 0, nil, ErrChunkCount},
+		{1, 5, nil, ErrChunkCount},
+		{3, 1, nil, AxisError{}},
+	}
+
+	for _, tab := range chunkTable {
+		res, err := tensor.ChunkSafe(tab.axis, tab.n)
+
+		if handleErrors(t, "Chunk", tab.err, err, "Axis: %d, N: %d.", tab.axis, tab.n) {
+			sizes := make([]int, len(res))
+			for i := range res {
+				sizes[i] = res[i].Dims[tab.axis]
+			}
+
+			handleReturn(t, "Chunk", tab.sizes, sizes, "Axis: %d, N: %d.", tab.axis, tab.n)
+		}
+	}
+}
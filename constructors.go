@@ -0,0 +1,283 @@
+package tensors
+
+import (
+	"math"
+	"reflect"
+)
+
+// Zeros returns a new Tensor with the given dimensions, where every value is zero. It is
+// identical to NewTensor, and is provided for symmetry with Ones and Full.
+func Zeros(dims []int) Tensor {
+	return NewTensor(dims)
+}
+
+// ZerosSafe undergoes the same process as Zeros, but returns error instead of panicking.
+func ZerosSafe(dims []int) (Tensor, error) {
+	return NewTensorSafe(dims)
+}
+
+// Scalar returns a new rank-0 Tensor, as given by NewTensorExtended, holding the single value
+// given.
+func Scalar(value float64) Tensor {
+	return Tensor{Interpreter: NewInterpreterExtended(nil), Values: []float64{value}}
+}
+
+// Ones returns a new Tensor with the given dimensions, where every value is one. Ones will panic
+// if any of the error conditions from NewInterpreterSafe are met.
+func Ones(dims []int) Tensor {
+	return Full(dims, 1)
+}
+
+// OnesSafe undergoes the same process as Ones, but returns error instead of panicking.
+func OnesSafe(dims []int) (Tensor, error) {
+	return FullSafe(dims, 1)
+}
+
+// Full returns a new Tensor with the given dimensions, where every value is equal to the value
+// given. Full will panic if any of the error conditions from NewInterpreterSafe are met.
+func Full(dims []int, value float64) Tensor {
+	t, err := FullSafe(dims, value)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// FullSafe undergoes the same process as Full, but returns error instead of panicking.
+func FullSafe(dims []int, value float64) (Tensor, error) {
+	t, err := NewTensorSafe(dims)
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	for i := range t.Values {
+		t.Values[i] = value
+	}
+
+	return t, nil
+}
+
+// is64Bit is 1 if int has 64 bits, and 0 if it has 32
+const is64Bit = maxInt >> 62
+
+// maxAllocBits is log2 of the largest allocation the Go runtime permits, in bytes, which is bound
+// by the address space of the platform: 48 bits on 64-bit platforms, and 31 bits (the positive
+// range of int) on 32-bit platforms.
+const maxAllocBits = 48*is64Bit + 31*(1-is64Bit)
+
+// maxValues is the largest number of float64 values, of 8 bytes each, that can be allocated in a
+// single slice.
+const maxValues = 1 << (maxAllocBits - 3)
+
+// Arange returns a new one-dimensional Tensor with values from start (inclusive) to stop
+// (exclusive), spaced by step. Step may be negative, in which case stop should be less than
+// start.
+//
+// Arange will panic with ErrNonFinite if any of start, stop or step is NaN or infinite,
+// ErrZeroStep if step is zero, and ErrEmptyRange if the range would contain no values. If the
+// number of values cannot be represented as an int, Arange will panic with an OverflowError, and
+// if it is too large to allocate, a LimitError. ArangeSafe returns these errors instead.
+func Arange(start, stop, step float64) Tensor {
+	t, err := ArangeSafe(start, stop, step)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// ArangeSafe undergoes the same process as Arange, but returns error instead of panicking.
+func ArangeSafe(start, stop, step float64) (Tensor, error) {
+	finite := func(x float64) bool { return !math.IsNaN(x) && !math.IsInf(x, 0) }
+	if !finite(start) || !finite(stop) || !finite(step) {
+		return Tensor{}, ErrNonFinite
+	} else if step == 0 {
+		return Tensor{}, ErrZeroStep
+	}
+
+	count := math.Ceil((stop - start) / step)
+	if count <= 0 {
+		return Tensor{}, ErrEmptyRange
+	} else if math.IsInf(count, 0) || count >= float64(maxInt) {
+		return Tensor{}, OverflowError{nil, 0}
+	}
+
+	n := int(count)
+	in, err := NewInterpreterLimitSafe([]int{n}, maxValues)
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	t := Tensor{Interpreter: in, Values: make([]float64, n)}
+	for i := range t.Values {
+		// multiplying instead of repeatedly adding avoids accumulating rounding errors
+		t.Values[i] = start + float64(i)*step
+	}
+
+	return t, nil
+}
+
+// Linspace returns a new one-dimensional Tensor with n values evenly spaced from start to stop,
+// inclusive. If n is 1, the only value will be start.
+//
+// Linspace will panic with a DimsValueError if n ≤ 0. LinspaceSafe returns the error instead.
+func Linspace(start, stop float64, n int) Tensor {
+	t, err := LinspaceSafe(start, stop, n)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// LinspaceSafe undergoes the same process as Linspace, but returns error instead of panicking.
+func LinspaceSafe(start, stop float64, n int) (Tensor, error) {
+	t, err := NewTensorSafe([]int{n})
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	if n == 1 {
+		t.Values[0] = start
+		return t, nil
+	}
+
+	step := (stop - start) / float64(n-1)
+	for i := range t.Values {
+		t.Values[i] = start + float64(i)*step
+	}
+
+	// make sure that the endpoint is exact
+	t.Values[n-1] = stop
+
+	return t, nil
+}
+
+// Eye returns a new n×n Tensor representing the identity matrix; values with equal indices in
+// both dimensions are one, and all others are zero.
+//
+// Eye will panic with a DimsValueError if n ≤ 0. EyeSafe returns the error instead.
+func Eye(n int) Tensor {
+	t, err := EyeSafe(n)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// EyeSafe undergoes the same process as Eye, but returns error instead of panicking.
+func EyeSafe(n int) (Tensor, error) {
+	t, err := NewTensorSafe([]int{n, n})
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	for i := 0; i < n; i++ {
+		t.Values[i*(n+1)] = 1
+	}
+
+	return t, nil
+}
+
+// FromNested returns a new Tensor built from a nested set of slices (or arrays) of float64, eg.
+// [][]float64 or [][][2]float64. The dimensions are inferred from the lengths of the slices.
+//
+// The outermost slice corresponds to the highest dimension, so that the innermost slices contain
+// values along Dims[0]. For example, [][]float64{{1, 2, 3}, {4, 5, 6}} gives a Tensor with Dims
+// [3, 2], which prints in the same layout that it was written in.
+//
+// FromNested will panic if any of the following conditions are met:
+//		(0) If nested is not a slice or array, or any of its elements are not float64 or slices of
+//			the same depth. This will cause ErrNestedType.
+//		(1) If any slice has a different length than the other slices at its depth. This will
+//			cause a RaggedError.
+//		(2) If any slice is empty. This will cause a DimsValueError, as the dimension would be
+//			zero.
+// FromNestedSafe returns these errors instead.
+func FromNested(nested interface{}) Tensor {
+	t, err := FromNestedSafe(nested)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// FromNestedSafe undergoes the same process as FromNested, but returns error instead of
+// panicking.
+func FromNestedSafe(nested interface{}) (Tensor, error) {
+	v := reflect.ValueOf(nested)
+
+	// infer the dimensions from the first element at each depth, outermost first. We'll check
+	// them all later.
+	var rev []int
+	for cur := v; ; cur = cur.Index(0) {
+		if cur.Kind() == reflect.Float64 {
+			break
+		} else if cur.Kind() != reflect.Slice && cur.Kind() != reflect.Array {
+			return Tensor{}, ErrNestedType
+		}
+
+		rev = append(rev, cur.Len())
+		if cur.Len() == 0 {
+			break
+		}
+	}
+
+	if len(rev) == 0 {
+		return Tensor{}, ErrNestedType
+	}
+
+	dims := make([]int, len(rev))
+	for i := range rev {
+		dims[i] = rev[len(rev)-1-i]
+	}
+
+	t, err := NewTensorSafe(dims)
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	index := 0
+	path := make([]int, 0, len(dims))
+
+	var fill func(cur reflect.Value, depth int) error
+	fill = func(cur reflect.Value, depth int) error {
+		if cur.Kind() != reflect.Slice && cur.Kind() != reflect.Array {
+			return ErrNestedType
+		} else if cur.Len() != rev[depth] {
+			p := make([]int, len(path))
+			copy(p, path)
+			return RaggedError{p, cur.Len(), rev[depth]}
+		}
+
+		for i := 0; i < cur.Len(); i++ {
+			elem := cur.Index(i)
+			if depth == len(rev)-1 {
+				if elem.Kind() != reflect.Float64 {
+					return ErrNestedType
+				}
+
+				t.Values[index] = elem.Float()
+				index++
+				continue
+			}
+
+			path = append(path, i)
+			if err := fill(elem, depth+1); err != nil {
+				return err
+			}
+			path = path[:len(path)-1]
+		}
+
+		return nil
+	}
+
+	if err := fill(v, 0); err != nil {
+		return Tensor{}, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,85 @@
+package tensors
+
+import (
+	"math"
+	"testing"
+)
+
+// requires NewInterpreter
+func tConstructors(t *testing.T) {
+	table := []struct {
+		name   string
+		t      Tensor
+		dims   []int
+		values []float64
+	}{
+		{"Zeros", Zeros([]int{2, 2}), []int{2, 2}, []float64{0, 0, 0, 0}},
+		{"Ones", Ones([]int{3}), []int{3}, []float64{1, 1, 1}},
+		{"Full", Full([]int{1, 2}, 2.5), []int{1, 2}, []float64{2.5, 2.5}},
+		{"Arange", Arange(0, 5, 2), []int{3}, []float64{0, 2, 4}},
+		{"Arange", Arange(1, 0, -0.25), []int{4}, []float64{1, 0.75, 0.5, 0.25}},
+		{"Linspace", Linspace(0, 1, 5), []int{5}, []float64{0, 0.25, 0.5, 0.75, 1}},
+		{"Linspace", Linspace(3, 4, 1), []int{1}, []float64{3}},
+		{"Eye", Eye(3), []int{3, 3}, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}},
+	}
+
+	for _, tab := range table {
+		_ = handleReturn(t, tab.name, tab.dims, tab.t.Dims, "") &&
+			handleReturn(t, tab.name, tab.values, tab.t.Values, "")
+	}
+
+	errTable := []struct {
+		name string
+		fn   func() (Tensor, error)
+		err  error
+	}{
+		{"Ones", func() (Tensor, error) { return OnesSafe(nil) }, ErrZeroDims},
+		{"Full", func() (Tensor, error) { return FullSafe([]int{2, 0}, 1) }, DimsValueError{}},
+		{"Arange", func() (Tensor, error) { return ArangeSafe(0, 1, 0) }, ErrZeroStep},
+		{"Arange", func() (Tensor, error) { return ArangeSafe(1, 0, 1) }, ErrEmptyRange},
+		{"Arange", func() (Tensor, error) { return ArangeSafe(0, 1e17, 1) }, LimitError{}},
+		{"Arange", func() (Tensor, error) { return ArangeSafe(0, 1e19, 1) }, OverflowError{}},
+		{"Arange", func() (Tensor, error) { return ArangeSafe(0, math.Inf(1), 1) }, ErrNonFinite},
+		{"Arange", func() (Tensor, error) { return ArangeSafe(0, math.NaN(), 1) }, ErrNonFinite},
+		{"Arange", func() (Tensor, error) { return ArangeSafe(0, 1, math.Inf(-1)) }, ErrNonFinite},
+		{"Linspace", func() (Tensor, error) { return LinspaceSafe(0, 1, 0) }, DimsValueError{}},
+		{"Eye", func() (Tensor, error) { return EyeSafe(-1) }, DimsValueError{}},
+	}
+
+	for _, tab := range errTable {
+		_, err := tab.fn()
+		handleErrors(t, tab.name, tab.err, err, "")
+	}
+}
+
+// requires NewInterpreter
+func tFromNested(t *testing.T) {
+	table := []struct {
+		nested interface{}
+		dims   []int
+		values []float64
+		err    error
+	}{
+		{[]float64{1, 2, 3}, []int{3}, []float64{1, 2, 3}, nil},
+		{[][]float64{{1, 2, 3}, {4, 5, 6}}, []int{3, 2}, []float64{1, 2, 3, 4, 5, 6}, nil},
+		{[2][1]float64{{1}, {2}}, []int{1, 2}, []float64{1, 2}, nil},
+		{[][][]float64{{{1, 2}}, {{3, 4}}, {{5, 6}}}, []int{2, 1, 3}, []float64{1, 2, 3, 4, 5, 6}, nil},
+
+		{nil, nil, nil, ErrNestedType},
+		{1.0, nil, nil, ErrNestedType},
+		{[]int{1, 2}, nil, nil, ErrNestedType},
+		{[]interface{}{1.0, []float64{1}}, nil, nil, ErrNestedType},
+		{[]float64{}, nil, nil, DimsValueError{}},
+		{[][]float64{{}, {}}, nil, nil, DimsValueError{}},
+		{[][]float64{{1, 2}, {3}}, nil, nil, RaggedError{}},
+		{[][][]float64{{{1}, {2}}, {{3}, {4, 5}}}, nil, nil, RaggedError{}},
+	}
+
+	for _, tab := range table {
+		tensor, err := FromNestedSafe(tab.nested)
+
+		_ = handleErrors(t, "FromNested", tab.err, err, "Nested: %v.", tab.nested) &&
+			handleReturn(t, "FromNested", tab.dims, tensor.Dims, "Nested: %v.", tab.nested) &&
+			handleReturn(t, "FromNested", tab.values, tensor.Values, "Nested: %v.", tab.nested)
+	}
+}
@@ -0,0 +1,519 @@
+package tensors
+
+// ConvOptions serves as an argument to Conv and its related functions, grouping the optional
+// parameters of a convolution. If ConvOptions is nil, the convolution has stride 1, no padding, a
+// dilation of 1 and a single group.
+//
+// Each of Stride, Padding and Dilation has one value for each spatial dimension. If any of them are
+// nil, they are set to their defaults for every spatial dimension.
+type ConvOptions struct {
+	// Stride is the distance, along each spatial dimension, between neighboring applications of
+	// the kernel. Each value must be ≥ 1.
+	Stride []int
+
+	// Padding is the number of implicit zeros added to both ends of each spatial dimension of the
+	// input. Each value must be ≥ 0.
+	Padding []int
+
+	// Dilation is the distance, along each spatial dimension, between neighboring elements of the
+	// kernel when it is applied. Each value must be ≥ 1.
+	Dilation []int
+
+	// Groups is the number of groups that the input channels and output channels are divided
+	// into, where each output channel is only connected to the input channels of its group.
+	// Groups must evenly divide both. If Groups is less than 1, it will be set to 1.
+	Groups int
+}
+
+// convGeometry stores the information about the shapes involved in a convolution that is shared
+// between Im2Col, Col2Im, Conv and its gradients.
+type convGeometry struct {
+	// in, kernel and out are Interpreters for only the spatial dimensions
+	in, kernel, out Interpreter
+
+	channels, batch int
+	groups          int
+
+	// offsets gives, for every pair of kernel point and output point, the index in the spatial
+	// dimensions of the input that they refer to, or -1 if it falls in the padding. The offset for
+	// kernel index k and output index o is at k + kernel.Size()*o.
+	offsets []int
+}
+
+// newConvGeometry checks the given dimensions and options, and returns the geometry of the
+// convolution. inputDims are the full dimensions of the input, including channels and batch, but
+// kernel contains only the spatial dimensions.
+func newConvGeometry(inputDims, kernel []int, opts *ConvOptions) (convGeometry, error) {
+	if opts == nil {
+		opts = &ConvOptions{}
+	}
+
+	k := len(kernel)
+	if k == 0 {
+		return convGeometry{}, ErrZeroDims
+	} else if len(inputDims) != k+2 {
+		return convGeometry{}, LengthMismatchError{"input dims", len(inputDims), k + 2}
+	}
+
+	fill := func(name string, s []int, def, min int) ([]int, error) {
+		if s == nil {
+			s = make([]int, k)
+			for i := range s {
+				s[i] = def
+			}
+		} else if len(s) != k {
+			return nil, LengthMismatchError{name, len(s), k}
+		}
+
+		for _, v := range s {
+			if v < min {
+				return nil, ErrConvOptions
+			}
+		}
+
+		return s, nil
+	}
+
+	stride, err := fill("stride", opts.Stride, 1, 1)
+	if err != nil {
+		return convGeometry{}, err
+	}
+
+	padding, err := fill("padding", opts.Padding, 0, 0)
+	if err != nil {
+		return convGeometry{}, err
+	}
+
+	dilation, err := fill("dilation", opts.Dilation, 1, 1)
+	if err != nil {
+		return convGeometry{}, err
+	}
+
+	var g convGeometry
+
+	if g.in, err = NewInterpreterSafe(inputDims[:k]); err != nil {
+		return convGeometry{}, err
+	} else if g.kernel, err = NewInterpreterSafe(kernel); err != nil {
+		return convGeometry{}, err
+	}
+
+	g.channels, g.batch = inputDims[k], inputDims[k+1]
+	if g.channels <= 0 {
+		return convGeometry{}, DimsValueError{inputDims, k}
+	} else if g.batch <= 0 {
+		return convGeometry{}, DimsValueError{inputDims, k + 1}
+	}
+
+	g.groups = opts.Groups
+	if g.groups < 1 {
+		g.groups = 1
+	}
+
+	if g.channels%g.groups != 0 {
+		return convGeometry{}, ErrConvGroups
+	}
+
+	outDims := make([]int, k)
+	for d := range outDims {
+		span := dilation[d]*(kernel[d]-1) + 1
+		if inputDims[d]+2*padding[d] < span {
+			return convGeometry{}, ErrConvOutputSize
+		}
+
+		outDims[d] = (inputDims[d]+2*padding[d]-span)/stride[d] + 1
+	}
+
+	g.out = NewInterpreter(outDims)
+
+	g.offsets = make([]int, g.kernel.Size()*g.out.Size())
+	outPoint := make([]int, k)
+	kernelPoint := make([]int, k)
+	for i := range g.offsets {
+		offset := 0
+		for d := 0; d < k; d++ {
+			x := outPoint[d]*stride[d] - padding[d] + kernelPoint[d]*dilation[d]
+			if x < 0 || x >= g.in.Dims[d] {
+				offset = -1
+				break
+			}
+
+			offset += x * g.in.stride(d)
+		}
+
+		g.offsets[i] = offset
+
+		// the kernel point varies fastest
+		if !g.kernel.IncrementFast(kernelPoint) {
+			g.out.IncrementFast(outPoint)
+		}
+	}
+
+	return g, nil
+}
+
+// rows returns the number of rows in the matrix given by Im2Col -- the size of the kernel times
+// the number of channels.
+func (g convGeometry) rows() int {
+	return g.kernel.Size() * g.channels
+}
+
+// cols returns the number of columns in the matrix given by Im2Col -- the number of output points
+// times the size of the batch.
+func (g convGeometry) cols() int {
+	return g.out.Size() * g.batch
+}
+
+// outDims returns the full dimensions of the output of the convolution with the given number of
+// filters.
+func (g convGeometry) outDims(filters int) []int {
+	return append(append([]int{}, g.out.Dims...), filters, g.batch)
+}
+
+// im2col is the internal implementation of Im2Col.
+func (g convGeometry) im2col(input []float64) []float64 {
+	kSize, oSize, iSize := g.kernel.Size(), g.out.Size(), g.in.Size()
+	rows := g.rows()
+
+	cols := make([]float64, rows*g.cols())
+	for n := 0; n < g.batch; n++ {
+		for c := 0; c < g.channels; c++ {
+			channel := input[iSize*(c+g.channels*n):]
+			for o := 0; o < oSize; o++ {
+				col := cols[rows*(o+oSize*n)+kSize*c:]
+				for k, offset := range g.offsets[kSize*o : kSize*(o+1)] {
+					if offset != -1 {
+						col[k] = channel[offset]
+					}
+				}
+			}
+		}
+	}
+
+	return cols
+}
+
+// col2im is the internal implementation of Col2Im; the adjoint of im2col.
+func (g convGeometry) col2im(cols []float64) []float64 {
+	kSize, oSize, iSize := g.kernel.Size(), g.out.Size(), g.in.Size()
+	rows := g.rows()
+
+	input := make([]float64, iSize*g.channels*g.batch)
+	for n := 0; n < g.batch; n++ {
+		for c := 0; c < g.channels; c++ {
+			channel := input[iSize*(c+g.channels*n):]
+			for o := 0; o < oSize; o++ {
+				col := cols[rows*(o+oSize*n)+kSize*c:]
+				for k, offset := range g.offsets[kSize*o : kSize*(o+1)] {
+					if offset != -1 {
+						channel[offset] += col[k]
+					}
+				}
+			}
+		}
+	}
+
+	return input
+}
+
+// Im2Col extracts every patch of the input that a kernel with the given spatial dimensions would
+// be applied to, returning them as the columns of a matrix.
+//
+// The input must have dimensions [spatial..., channels, batch], where there are len(kernel)
+// spatial dimensions. The result has Dims [prod(kernel) * channels, prod(out) * batch], where out
+// are the spatial dimensions of the output of the convolution. Each column is a single patch,
+// with values ordered as they would be in a Tensor with Dims [kernel..., channels]. Values that
+// fall in the padding are zero.
+//
+// Im2Col will panic if any of the error conditions from ConvSafe are met. Im2ColSafe returns error
+// instead.
+func Im2Col(input Tensor, kernel []int, opts *ConvOptions) Tensor {
+	t, err := Im2ColSafe(input, kernel, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// Im2ColSafe undergoes the same process as Im2Col, but returns error instead of panicking.
+func Im2ColSafe(input Tensor, kernel []int, opts *ConvOptions) (Tensor, error) {
+	if err := checkLayout(input.Interpreter); err != nil {
+		return Tensor{}, opError("Im2Col", err, input.Interpreter)
+	}
+
+	g, err := newConvGeometry(input.Dims, kernel, opts)
+	if err != nil {
+		return Tensor{}, opError("Im2Col", err, input.Interpreter)
+	}
+
+	in := NewInterpreter([]int{g.rows(), g.cols()})
+	return Tensor{Interpreter: in, Values: g.im2col(input.Values)}, nil
+}
+
+// Col2Im is the adjoint of Im2Col. It takes a matrix of patches in the format given by Im2Col, and
+// returns a Tensor with the given input dimensions, where each value is the sum of every patch
+// value that was taken from that point. Values that fall in the padding are dropped.
+//
+// Col2Im will panic if any of the error conditions from ConvSafe are met, or with a
+// ShapeMismatchError if cols does not have the dimensions that Im2Col would give. Col2ImSafe
+// returns error instead.
+func Col2Im(cols Tensor, inputDims, kernel []int, opts *ConvOptions) Tensor {
+	t, err := Col2ImSafe(cols, inputDims, kernel, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// Col2ImSafe undergoes the same process as Col2Im, but returns error instead of panicking.
+func Col2ImSafe(cols Tensor, inputDims, kernel []int, opts *ConvOptions) (Tensor, error) {
+	if err := checkLayout(cols.Interpreter); err != nil {
+		return Tensor{}, opError("Col2Im", err, cols.Interpreter)
+	}
+
+	g, err := newConvGeometry(inputDims, kernel, opts)
+	if err != nil {
+		return Tensor{}, opError("Col2Im", err, cols.Interpreter)
+	}
+
+	dims := []int{g.rows(), g.cols()}
+	if !Equals(cols.Interpreter, NewInterpreter(dims)) {
+		return Tensor{}, opError("Col2Im", ShapeMismatchError{0, -1, cols.Dims, dims},
+			cols.Interpreter)
+	}
+
+	in, err := NewInterpreterSafe(inputDims)
+	if err != nil {
+		return Tensor{}, opError("Col2Im", err, cols.Interpreter)
+	}
+
+	return Tensor{Interpreter: in, Values: g.col2im(cols.Values)}, nil
+}
+
+// checkWeight checks that the weight dimensions are compatible with the input of the convolution,
+// returning the number of filters.
+func (g convGeometry) checkWeight(weightDims, inputDims []int) (int, error) {
+	k := len(g.kernel.Dims)
+	if len(weightDims) != k+2 {
+		return 0, ShapeMismatchError{1, -1, weightDims, inputDims}
+	} else if weightDims[k]*g.groups != g.channels {
+		return 0, ShapeMismatchError{1, k, weightDims, inputDims}
+	}
+
+	filters := weightDims[k+1]
+	if filters <= 0 {
+		return 0, DimsValueError{weightDims, k + 1}
+	} else if filters%g.groups != 0 {
+		return 0, ErrConvGroups
+	}
+
+	return filters, nil
+}
+
+// Conv performs an N-dimensional convolution (technically cross-correlation, as is typical) of the
+// input with the weight, returning the result. Any number of spatial dimensions may be used.
+//
+// The input has dimensions [spatial..., channels, batch] and the weight has dimensions
+// [kernel..., channels / groups, filters]. The result has dimensions [out..., filters, batch],
+// where out are the spatial dimensions of the output. Along each spatial dimension d:
+//		out[d] = (spatial[d] + 2*padding[d] - dilation[d]*(kernel[d]-1) - 1) / stride[d] + 1
+//
+// Conv is implemented by extracting patches with Im2Col, then multiplying them with the weight.
+// Conv will panic if any of the following conditions are met:
+//		(0) If the weight has no spatial dimensions, this will cause ErrZeroDims.
+//		(1) If the input does not have 2 more dimensions than the kernel, or any of the options
+//			have the wrong number of values, this will cause a LengthMismatchError.
+//		(2) If the weight does not have the right number of dimensions or channels, this will
+//			cause a ShapeMismatchError naming the weight as operand 1.
+//		(3) If any of the options are out of range, this will cause ErrConvOptions.
+//		(4) If the number of input channels or filters is not divisible by the number of groups,
+//			this will cause ErrConvGroups.
+//		(5) If the kernel is larger than the padded input, this will cause ErrConvOutputSize.
+// ConvSafe returns these errors instead.
+func Conv(input, weight Tensor, opts *ConvOptions) Tensor {
+	t, err := ConvSafe(input, weight, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// ConvSafe undergoes the same process as Conv, but returns error instead of panicking.
+func ConvSafe(input, weight Tensor, opts *ConvOptions) (Tensor, error) {
+	k := len(weight.Dims) - 2
+	if err := checkLayout(input.Interpreter, weight.Interpreter); err != nil {
+		return Tensor{}, opError("Conv", err, input.Interpreter, weight.Interpreter)
+	} else if k < 1 {
+		return Tensor{}, opError("Conv", ErrZeroDims, input.Interpreter, weight.Interpreter)
+	}
+
+	g, err := newConvGeometry(input.Dims, weight.Dims[:k], opts)
+	if err != nil {
+		return Tensor{}, opError("Conv", err, input.Interpreter, weight.Interpreter)
+	}
+
+	filters, err := g.checkWeight(weight.Dims, input.Dims)
+	if err != nil {
+		return Tensor{}, opError("Conv", err, input.Interpreter, weight.Interpreter)
+	}
+
+	cols := g.im2col(input.Values)
+	out := NewTensor(g.outDims(filters))
+
+	rows, oSize := g.rows(), g.out.Size()
+	groupRows, groupFilters := rows/g.groups, filters/g.groups
+
+	for n := 0; n < g.batch; n++ {
+		for o := 0; o < oSize; o++ {
+			col := cols[rows*(o+oSize*n):]
+			for f := 0; f < filters; f++ {
+				patch := col[groupRows*(f/groupFilters) : groupRows*(f/groupFilters+1)]
+				w := weight.Values[groupRows*f:]
+
+				var sum float64
+				for r, v := range patch {
+					sum += w[r] * v
+				}
+
+				out.Values[o+oSize*(f+filters*n)] = sum
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// checkGradOut checks that gradOut has the dimensions of the output of the convolution.
+func (g convGeometry) checkGradOut(gradOut Tensor, filters int) error {
+	dims := g.outDims(filters)
+	if !Equals(gradOut.Interpreter, NewInterpreter(dims)) {
+		return ShapeMismatchError{0, -1, gradOut.Dims, dims}
+	}
+
+	return nil
+}
+
+// ConvGradInput returns the gradient of the convolution with respect to its input, given the
+// gradient with respect to its output, the weight, and the dimensions of the input. The result
+// has dimensions inputDims.
+//
+// ConvGradInput will panic under the same conditions as Conv, or with a ShapeMismatchError naming
+// gradOut as operand 0 if it does not have the dimensions of the output of the convolution.
+// ConvGradInputSafe returns these errors instead.
+func ConvGradInput(gradOut, weight Tensor, inputDims []int, opts *ConvOptions) Tensor {
+	t, err := ConvGradInputSafe(gradOut, weight, inputDims, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// ConvGradInputSafe undergoes the same process as ConvGradInput, but returns error instead of
+// panicking.
+func ConvGradInputSafe(gradOut, weight Tensor, inputDims []int, opts *ConvOptions) (Tensor, error) {
+	k := len(weight.Dims) - 2
+	if err := checkLayout(gradOut.Interpreter, weight.Interpreter); err != nil {
+		return Tensor{}, opError("ConvGradInput", err, gradOut.Interpreter, weight.Interpreter)
+	} else if k < 1 {
+		return Tensor{}, opError("ConvGradInput", ErrZeroDims, gradOut.Interpreter,
+			weight.Interpreter)
+	}
+
+	g, err := newConvGeometry(inputDims, weight.Dims[:k], opts)
+	if err != nil {
+		return Tensor{}, opError("ConvGradInput", err, gradOut.Interpreter, weight.Interpreter)
+	}
+
+	filters, err := g.checkWeight(weight.Dims, inputDims)
+	if err != nil {
+		return Tensor{}, opError("ConvGradInput", err, gradOut.Interpreter, weight.Interpreter)
+	} else if err = g.checkGradOut(gradOut, filters); err != nil {
+		return Tensor{}, opError("ConvGradInput", err, gradOut.Interpreter, weight.Interpreter)
+	}
+
+	rows, oSize := g.rows(), g.out.Size()
+	groupRows, groupFilters := rows/g.groups, filters/g.groups
+
+	cols := make([]float64, rows*g.cols())
+	for n := 0; n < g.batch; n++ {
+		for o := 0; o < oSize; o++ {
+			col := cols[rows*(o+oSize*n):]
+			for f := 0; f < filters; f++ {
+				grad := gradOut.Values[o+oSize*(f+filters*n)]
+				patch := col[groupRows*(f/groupFilters) : groupRows*(f/groupFilters+1)]
+				w := weight.Values[groupRows*f:]
+
+				for r := range patch {
+					patch[r] += w[r] * grad
+				}
+			}
+		}
+	}
+
+	return Tensor{Interpreter: NewInterpreter(inputDims), Values: g.col2im(cols)}, nil
+}
+
+// ConvGradWeight returns the gradient of the convolution with respect to its weight, given the
+// input, the gradient with respect to its output, and the dimensions of the weight. The result has
+// dimensions weightDims.
+//
+// ConvGradWeight will panic under the same conditions as ConvGradInput. ConvGradWeightSafe returns
+// these errors instead.
+func ConvGradWeight(input, gradOut Tensor, weightDims []int, opts *ConvOptions) Tensor {
+	t, err := ConvGradWeightSafe(input, gradOut, weightDims, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// ConvGradWeightSafe undergoes the same process as ConvGradWeight, but returns error instead of
+// panicking.
+func ConvGradWeightSafe(input, gradOut Tensor, weightDims []int, opts *ConvOptions) (Tensor, error) {
+	k := len(weightDims) - 2
+	if err := checkLayout(input.Interpreter, gradOut.Interpreter); err != nil {
+		return Tensor{}, opError("ConvGradWeight", err, input.Interpreter, gradOut.Interpreter)
+	} else if k < 1 {
+		return Tensor{}, opError("ConvGradWeight", ErrZeroDims, input.Interpreter,
+			gradOut.Interpreter)
+	}
+
+	g, err := newConvGeometry(input.Dims, weightDims[:k], opts)
+	if err != nil {
+		return Tensor{}, opError("ConvGradWeight", err, input.Interpreter, gradOut.Interpreter)
+	}
+
+	filters, err := g.checkWeight(weightDims, input.Dims)
+	if err != nil {
+		return Tensor{}, opError("ConvGradWeight", err, input.Interpreter, gradOut.Interpreter)
+	} else if err = g.checkGradOut(gradOut, filters); err != nil {
+		return Tensor{}, opError("ConvGradWeight", err, input.Interpreter, gradOut.Interpreter)
+	}
+
+	cols := g.im2col(input.Values)
+	grad := NewTensor(weightDims)
+
+	rows, oSize := g.rows(), g.out.Size()
+	groupRows, groupFilters := rows/g.groups, filters/g.groups
+
+	for n := 0; n < g.batch; n++ {
+		for o := 0; o < oSize; o++ {
+			col := cols[rows*(o+oSize*n):]
+			for f := 0; f < filters; f++ {
+				gOut := gradOut.Values[o+oSize*(f+filters*n)]
+				patch := col[groupRows*(f/groupFilters) : groupRows*(f/groupFilters+1)]
+				w := grad.Values[groupRows*f:]
+
+				for r, v := range patch {
+					w[r] += v * gOut
+				}
+			}
+		}
+	}
+
+	return grad, nil
+}
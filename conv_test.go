@@ -0,0 +1,135 @@
+package tensors
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// requires Constructors, Random
+func tConv(t *testing.T) {
+	seq := Tensor{Interpreter: NewInterpreter([]int{5, 1, 1}), Values: []float64{1, 2, 3, 4, 5}}
+
+	table := []struct {
+		input, weight Tensor
+		opts          *ConvOptions
+		res           []float64
+		err           error
+	}{
+		{seq, Tensor{Interpreter: NewInterpreter([]int{2, 1, 1}), Values: []float64{1, -1}}, nil, []float64{-1, -1, -1, -1}, nil},
+		{seq, Ones([]int{3, 1, 1}), &ConvOptions{Stride: []int{2}, Padding: []int{1}}, []float64{3, 9, 9}, nil},
+		{seq, Ones([]int{2, 1, 1}), &ConvOptions{Dilation: []int{2}}, []float64{4, 6, 8}, nil},
+		{seq, Ones([]int{5, 1, 1}), nil, []float64{15}, nil},
+
+		{seq, Ones([]int{1, 1}), nil, nil, ErrZeroDims},
+		{seq, Ones([]int{1, 1, 1, 1}), nil, nil, LengthMismatchError{}},
+		{seq, Ones([]int{2, 1, 1}), &ConvOptions{Stride: []int{1, 1}}, nil, LengthMismatchError{}},
+		{seq, Ones([]int{2, 2, 1}), nil, nil, ShapeMismatchError{}},
+		{seq, Ones([]int{2, 1, 1}), &ConvOptions{Stride: []int{0}}, nil, ErrConvOptions},
+		{seq, Ones([]int{2, 1, 1}), &ConvOptions{Padding: []int{-1}}, nil, ErrConvOptions},
+		{seq, Ones([]int{2, 1, 1}), &ConvOptions{Groups: 2}, nil, ErrConvGroups},
+		{seq, Ones([]int{6, 1, 1}), nil, nil, ErrConvOutputSize},
+		{seq, Ones([]int{3, 1, 1}), &ConvOptions{Dilation: []int{3}}, nil, ErrConvOutputSize},
+	}
+
+	for _, tab := range table {
+		res, err := ConvSafe(tab.input, tab.weight, tab.opts)
+
+		_ = handleErrors(t, "Conv", tab.err, err, "Weight: %v, Options: %+v.", tab.weight.Dims, tab.opts) &&
+			handleReturn(t, "Conv", tab.res, res.Values, "Weight: %v, Options: %+v.", tab.weight.Dims, tab.opts)
+	}
+
+	// compare a 2-D grouped convolution with a direct implementation
+	rng := rand.New(rand.NewSource(1))
+	input := NewRandom([]int{7, 6, 4, 2}, Uniform(-1, 1), rng)
+	weight := NewRandom([]int{3, 2, 2, 6}, Uniform(-1, 1), rng)
+	opts := &ConvOptions{Stride: []int{2, 1}, Padding: []int{1, 2}, Dilation: []int{1, 2}, Groups: 2}
+
+	res := Conv(input, weight, opts)
+	handleReturn(t, "Conv", []int{4, 8, 6, 2}, res.Dims, "")
+
+	for i, v := range res.Values {
+		p := res.Point(i)
+		x, y, f, n := p[0], p[1], p[2], p[3]
+
+		var sum float64
+		for c := 0; c < 2; c++ {
+			for ky := 0; ky < 2; ky++ {
+				for kx := 0; kx < 3; kx++ {
+					ix, iy := x*2-1+kx, y-2+ky*2
+					if ix < 0 || ix >= 7 || iy < 0 || iy >= 6 {
+						continue
+					}
+
+					sum += input.PointValue([]int{ix, iy, c + 2*(f/3), n}) * weight.PointValue([]int{kx, ky, c, f})
+				}
+			}
+		}
+
+		if math.Abs(sum-v) > 1e-12 {
+			t.Errorf("Conv: Bad value at point %v. Expected %v, Got %v.", p, sum, v)
+		}
+	}
+}
+
+// requires Conv
+func tConvGrad(t *testing.T) {
+	dot := func(a, b Tensor) float64 {
+		var sum float64
+		for i := range a.Values {
+			sum += a.Values[i] * b.Values[i]
+		}
+		return sum
+	}
+
+	rng := rand.New(rand.NewSource(2))
+
+	table := []struct {
+		inputDims, weightDims []int
+		opts                  *ConvOptions
+	}{
+		{[]int{9, 2, 3}, []int{3, 2, 4}, nil},
+		{[]int{6, 5, 4, 2}, []int{3, 2, 2, 4}, &ConvOptions{Stride: []int{2, 2}, Padding: []int{1, 0}, Groups: 2}},
+		{[]int{4, 5, 3, 2, 1}, []int{2, 2, 2, 2, 2}, &ConvOptions{Dilation: []int{2, 1, 1}, Padding: []int{0, 1, 1}}},
+	}
+
+	// the convolution is linear in both its input and its weight, so the gradients are its
+	// adjoints: <Conv(x, w), g> = <x, GradInput(g, w)> = <w, GradWeight(x, g)>
+	for _, tab := range table {
+		x := NewRandom(tab.inputDims, Normal(0, 1), rng)
+		w := NewRandom(tab.weightDims, Normal(0, 1), rng)
+		out := Conv(x, w, tab.opts)
+		g := NewRandom(out.Dims, Normal(0, 1), rng)
+
+		gradInput, err := ConvGradInputSafe(g, w, tab.inputDims, tab.opts)
+		if handleErrors(t, "ConvGradInput", nil, err, "") {
+			if a, b := dot(out, g), dot(x, gradInput); math.Abs(a-b) > 1e-9 {
+				t.Errorf("ConvGradInput: Not the adjoint of Conv. Input: %v, Expected %v, Got %v.", x.Dims, a, b)
+			}
+		}
+
+		gradWeight, err := ConvGradWeightSafe(x, g, tab.weightDims, tab.opts)
+		if handleErrors(t, "ConvGradWeight", nil, err, "") {
+			if a, b := dot(out, g), dot(w, gradWeight); math.Abs(a-b) > 1e-9 {
+				t.Errorf("ConvGradWeight: Not the adjoint of Conv. Input: %v, Expected %v, Got %v.", x.Dims, a, b)
+			}
+		}
+
+		// Col2Im is the adjoint of Im2Col
+		cols := Im2Col(x, tab.weightDims[:len(tab.weightDims)-2], tab.opts)
+		c := NewRandom(cols.Dims, Normal(0, 1), rng)
+		im, err := Col2ImSafe(c, tab.inputDims, tab.weightDims[:len(tab.weightDims)-2], tab.opts)
+		if handleErrors(t, "Col2Im", nil, err, "") {
+			if a, b := dot(cols, c), dot(x, im); math.Abs(a-b) > 1e-9 {
+				t.Errorf("Col2Im: Not the adjoint of Im2Col. Input: %v, Expected %v, Got %v.", x.Dims, a, b)
+			}
+		}
+
+		_, err = ConvGradInputSafe(Zeros([]int{1, 1, 1}), w, tab.inputDims, tab.opts)
+		handleErrors(t, "ConvGradInput", ShapeMismatchError{}, err, "")
+		_, err = ConvGradWeightSafe(x, Zeros([]int{1, 1, 1}), tab.weightDims, tab.opts)
+		handleErrors(t, "ConvGradWeight", ShapeMismatchError{}, err, "")
+		_, err = Col2ImSafe(Zeros([]int{1, 1}), tab.inputDims, tab.weightDims[:len(tab.weightDims)-2], tab.opts)
+		handleErrors(t, "Col2Im", ShapeMismatchError{}, err, "")
+	}
+}
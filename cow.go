@@ -0,0 +1,67 @@
+package tensors
+
+import (
+	"sync"
+)
+
+// sharing tracks the number of Tensors from Share that are using the same Values
+type sharing struct {
+	mux  sync.Mutex
+	refs int
+}
+
+// Share returns a copy of the Tensor that shares its Values, copy-on-write: the values are only
+// copied once either Tensor is written through a method that takes *Tensor (eg. Fill, Scatter,
+// ScatterAdd, and Unshare itself) or updated by an Optimizer, at which point the writer receives
+// its own copy. Any number of Tensors may share the same Values in this way.
+//
+// Writing to Values directly, or through any function that does not take *Tensor, is not tracked;
+// call Unshare first. Plain assignment of a Tensor still aliases its Values without sharing, and
+// Tensors that are dropped without being written are still counted, so the last Tensor using
+// some Values may make one unnecessary copy. Reshaping a shared Tensor counts the result as one
+// more Tensor sharing the Values; reshaping an unshared Tensor aliases them, as assignment does.
+func (t *Tensor) Share() Tensor {
+	if t.shared == nil {
+		t.shared = &sharing{refs: 1}
+	}
+
+	t.shared.mux.Lock()
+	t.shared.refs++
+	t.shared.mux.Unlock()
+
+	return *t
+}
+
+// Unshare ensures that the Tensor has its own Values, copying them if they are currently shared
+// with any other Tensor from Share. Unshare should be called before writing to Values directly.
+func (t *Tensor) Unshare() {
+	s := t.shared
+	if s == nil {
+		return
+	}
+
+	t.shared = nil
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.refs > 1 {
+		s.refs--
+
+		values := make([]float64, len(t.Values))
+		copy(values, t.Values)
+		t.Values = values
+	}
+}
+
+// IsShared returns whether or not the Values of the Tensor are currently shared with any other
+// Tensor from Share.
+func (t Tensor) IsShared() bool {
+	if t.shared == nil {
+		return false
+	}
+
+	t.shared.mux.Lock()
+	defer t.shared.mux.Unlock()
+	return t.shared.refs > 1
+}
@@ -0,0 +1,82 @@
+package tensors
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// requires FromNested, Random, Scatter
+func tShare(t *testing.T) {
+	a := FromNested([]float64{1, 2, 3})
+	b := a.Share()
+
+	if !a.IsShared() || !b.IsShared() {
+		t.Errorf("Share: Expected both Tensors to be shared.")
+	} else if &a.Values[0] != &b.Values[0] {
+		t.Errorf("Share: Expected Values to be shared before writing.")
+	}
+
+	// the writer gets its own copy; the other keeps the original values
+	b.Fill(Uniform(5, 6), rand.New(rand.NewSource(1)))
+	handleReturn(t, "Share", []float64{1, 2, 3}, a.Values, "Original after write.")
+	if b.Values[0] < 5 || b.IsShared() {
+		t.Errorf("Share: Writer did not receive its own values. Got %v.", b.Values)
+	}
+
+	// once the only user, the original is written in place
+	values := a.Values
+	if a.IsShared() {
+		t.Errorf("Share: Expected last user to not be shared.")
+	}
+	a.Unshare()
+	if &a.Values[0] != &values[0] {
+		t.Errorf("Unshare: Last user of shared values made a copy.")
+	}
+
+	// three-way sharing, written with Scatter
+	c := FromNested([]float64{0, 0})
+	d, e := c.Share(), c.Share()
+	d.Scatter(0, NewIndices([]int{1}, []int{1}), FromNested([]float64{7}))
+	handleReturn(t, "Share", []float64{0, 7}, d.Values, "Writer.")
+	handleReturn(t, "Share", []float64{0, 0}, c.Values, "Original.")
+	handleReturn(t, "Share", []float64{0, 0}, e.Values, "Other copy.")
+	if !c.IsShared() || !e.IsShared() {
+		t.Errorf("Share: Expected remaining Tensors to still be shared.")
+	}
+
+	// reshaped views of shared Tensors are counted as sharers
+	f := FromNested([][]float64{{1, 2}, {3, 4}})
+	g := f.Share()
+	v := f.Reshape([]int{4})
+	v.ScatterAdd(0, NewIndices([]int{1}, []int{0}), FromNested([]float64{10}))
+	handleReturn(t, "Share", FromNested([][]float64{{1, 2}, {3, 4}}).Values, g.Values, "Reshaped write.")
+
+	u := NewTensor([]int{4})
+	w := u.Share()
+	r := u.Reshape([]int{2, 2})
+	r.SetPoint([]int{1, 1}, 5)
+	u.SetPoint([]int{0}, 7)
+	handleReturn(t, "Share", []float64{0, 0, 0, 0}, w.Values, "Write after Reshape.")
+	handleReturn(t, "Share", []float64{0, 0, 0, 5}, r.Values, "Reshaped writer.")
+	handleReturn(t, "Share", []float64{7, 0, 0, 0}, u.Values, "Original writer.")
+
+	// concurrent writers, checked with -race
+	h := NewTensor([]int{100})
+	shares := make([]Tensor, 8)
+	for i := range shares {
+		shares[i] = h.Share()
+	}
+
+	var wg sync.WaitGroup
+	for i := range shares {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			shares[i].FillParallel(Uniform(0, 1), int64(i), nil)
+		}(i)
+	}
+	wg.Wait()
+
+	handleReturn(t, "Share", NewTensor([]int{100}).Values, h.Values, "Concurrent writes.")
+}
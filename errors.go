@@ -1,6 +1,7 @@
 package tensors
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 )
@@ -28,6 +29,88 @@ type PointOutOfBoundsError struct {
 	index int
 }
 
+// RaggedError serves to document errors from nested slices given to FromNested that do not all
+// have the same length at a given depth.
+type RaggedError struct {
+	path     []int
+	is       int
+	shouldBe int
+}
+
+// AxisError serves to document errors from an axis being given that is not a dimension of the
+// Interpreter -- either less than 0 or greater than or equal to the number of dimensions.
+type AxisError struct {
+	axis int
+	rank int
+}
+
+// ShapeMismatchError serves to document errors from operations on multiple Tensors, where the
+// dimensions of one operand are incompatible with the others along some axis. operand is the
+// position of the offending Tensor in the list of arguments. If the mismatch is not specific to a
+// single axis (eg. if the number of dimensions differs), axis is -1.
+type ShapeMismatchError struct {
+	operand int
+	axis    int
+
+	dims     []int
+	shouldBe []int
+}
+
+// OverflowError serves to document errors from dimensions whose product (the number of values)
+// cannot be represented as an int. index is the first dimension at which the product overflows.
+type OverflowError struct {
+	dims  []int
+	index int
+}
+
+// LimitError serves to document errors from dimensions that give more values than a limit allows.
+type LimitError struct {
+	dims  []int
+	limit int
+}
+
+// ParamError serves to document errors from Optimizers, naming the parameter that caused them.
+type ParamError struct {
+	name string
+	err  error
+}
+
+// OpError serves to add context to errors from operations on Tensors, wrapping the underlying
+// error with the name of the operation and the dimensions of each of its operands. The underlying
+// error is given by Unwrap, so it can still be identified by Is, errors.Is and errors.As.
+//
+// Every error from an operation on Tensors, ComplexTensors, COO or CSR matrices -- including the
+// losses, which take Tensors as arguments -- is wrapped in an OpError, whether or not its
+// documentation says so. Errors that are not from operations are not wrapped. These are errors
+// from constructors (including NewIndices, NewCOO and Pool.Get), from the methods of Interpreter,
+// from PointValue, SetPoint, Fill and MapApply, from Optimizers (which give a ParamError instead),
+// from GradCheck, and those specific to Variables, such as ErrTapeMismatch and ErrNotScalar. The
+// operations on Variables return the errors of the operations on Tensors that they use, so those
+// are wrapped. Errors from the linalg package are not wrapped.
+type OpError struct {
+	op   string
+	dims [][]int
+	err  error
+}
+
+// opError wraps err in an OpError, unless err is nil. If err is already an OpError -- from an
+// operation used to implement this one -- it is replaced, so that the error names the operation
+// that was called, instead of being wrapped twice.
+func opError(op string, err error, operands ...Interpreter) error {
+	if err == nil {
+		return nil
+	} else if e, ok := err.(OpError); ok {
+		err = e.err
+	}
+
+	dims := make([][]int, len(operands))
+	for i, in := range operands {
+		dims[i] = in.Dims
+	}
+
+	return OpError{op, dims, err}
+}
+
 func (err DimsValueError) Error() string {
 	return fmt.Sprintf("dims[%d] ≤ 0. dims: %v", err.index, err.dims)
 }
@@ -41,18 +124,185 @@ func (err PointOutOfBoundsError) Error() string {
 		err.index, err.point[err.index], err.index, err.dims[err.index])
 }
 
+func (err RaggedError) Error() string {
+	return fmt.Sprintf("nested slice at %v has length %d, should be %d", err.path, err.is, err.shouldBe)
+}
+
+func (err AxisError) Error() string {
+	return fmt.Sprintf("axis %d is out of bounds for %d dimensions", err.axis, err.rank)
+}
+
+func (err ShapeMismatchError) Error() string {
+	if err.axis < 0 {
+		return fmt.Sprintf("operand %d dims %v do not match %v", err.operand, err.dims, err.shouldBe)
+	}
+
+	return fmt.Sprintf("operand %d dims %v do not match %v along axis %d",
+		err.operand, err.dims, err.shouldBe, err.axis)
+}
+
+func (err OverflowError) Error() string {
+	return fmt.Sprintf("number of values overflows int at dims[%d]. dims: %v", err.index, err.dims)
+}
+
+func (err LimitError) Error() string {
+	return fmt.Sprintf("dims %v have more than %d values", err.dims, err.limit)
+}
+
+func (err ParamError) Error() string {
+	return fmt.Sprintf("parameter %q: %v", err.name, err.err)
+}
+
+func (err OpError) Error() string {
+	return fmt.Sprintf("%s: operand dims %v: %v", err.op, err.dims, err.err)
+}
+
+// Is methods allow errors.Is to identify errors from this package in the same way that Is does:
+// by type, for all but Error.
+func (err DimsValueError) Is(target error) bool {
+	_, ok := target.(DimsValueError)
+	return ok
+}
+
+func (err LengthMismatchError) Is(target error) bool {
+	_, ok := target.(LengthMismatchError)
+	return ok
+}
+
+func (err PointOutOfBoundsError) Is(target error) bool {
+	_, ok := target.(PointOutOfBoundsError)
+	return ok
+}
+
+func (err RaggedError) Is(target error) bool {
+	_, ok := target.(RaggedError)
+	return ok
+}
+
+func (err AxisError) Is(target error) bool {
+	_, ok := target.(AxisError)
+	return ok
+}
+
+func (err ShapeMismatchError) Is(target error) bool {
+	_, ok := target.(ShapeMismatchError)
+	return ok
+}
+
+func (err OverflowError) Is(target error) bool {
+	_, ok := target.(OverflowError)
+	return ok
+}
+
+func (err LimitError) Is(target error) bool {
+	_, ok := target.(LimitError)
+	return ok
+}
+
+func (err ParamError) Is(target error) bool {
+	_, ok := target.(ParamError)
+	return ok
+}
+
+func (err OpError) Is(target error) bool {
+	_, ok := target.(OpError)
+	return ok
+}
+
+// Unwrap returns the error that the ParamError wraps.
+func (err ParamError) Unwrap() error { return err.err }
+
+// Unwrap returns the error that the OpError wraps.
+func (err OpError) Unwrap() error { return err.err }
+
+// Accessors for the fields of each error, for use with errors.As. Slices are not copied, and
+// should not be modified.
+
+// Dims returns the dimensions that contained a non-positive value.
+func (err DimsValueError) Dims() []int { return err.dims }
+
+// Index returns the index of the first non-positive dimension.
+func (err DimsValueError) Index() int { return err.index }
+
+// Variant returns the description of what had a mismatched length (eg. "values").
+func (err LengthMismatchError) Variant() string { return err.variant }
+
+// Length returns the length that was given.
+func (err LengthMismatchError) Length() int { return err.is }
+
+// ShouldBe returns the length that was expected.
+func (err LengthMismatchError) ShouldBe() int { return err.shouldBe }
+
+// Point returns the point that was out of bounds.
+func (err PointOutOfBoundsError) Point() []int { return err.point }
+
+// Dims returns the dimensions that the point was out of bounds of.
+func (err PointOutOfBoundsError) Dims() []int { return err.dims }
+
+// Index returns the index of the first value of the point that was out of bounds.
+func (err PointOutOfBoundsError) Index() int { return err.index }
+
+// Path returns the position of the ragged slice within the nested value.
+func (err RaggedError) Path() []int { return err.path }
+
+// Length returns the length of the ragged slice.
+func (err RaggedError) Length() int { return err.is }
+
+// ShouldBe returns the length that the ragged slice should have had.
+func (err RaggedError) ShouldBe() int { return err.shouldBe }
+
+// Axis returns the axis that was out of bounds.
+func (err AxisError) Axis() int { return err.axis }
+
+// Rank returns the number of dimensions that the axis was out of bounds of.
+func (err AxisError) Rank() int { return err.rank }
+
+// Operand returns the position of the offending Tensor in the list of arguments.
+func (err ShapeMismatchError) Operand() int { return err.operand }
+
+// Axis returns the axis along which the dimensions did not match, or -1 if the mismatch was not
+// specific to a single axis.
+func (err ShapeMismatchError) Axis() int { return err.axis }
+
+// Dims returns the dimensions of the offending Tensor.
+func (err ShapeMismatchError) Dims() []int { return err.dims }
+
+// ShouldBe returns the dimensions that the offending Tensor was expected to match.
+func (err ShapeMismatchError) ShouldBe() []int { return err.shouldBe }
+
+// Dims returns the dimensions whose product overflowed.
+func (err OverflowError) Dims() []int { return err.dims }
+
+// Index returns the first dimension at which the product overflowed.
+func (err OverflowError) Index() int { return err.index }
+
+// Dims returns the dimensions that had too many values.
+func (err LimitError) Dims() []int { return err.dims }
+
+// Limit returns the maximum number of values that was allowed.
+func (err LimitError) Limit() int { return err.limit }
+
+// Name returns the name of the parameter that caused the error.
+func (err ParamError) Name() string { return err.name }
+
+// Op returns the name of the operation that failed.
+func (err OpError) Op() string { return err.op }
+
+// Dims returns the dimensions of each operand of the operation.
+func (err OpError) Dims() [][]int { return err.dims }
+
 // Is checks whether or not two errors from this package are the same type. This is more than just
 // a simple type comparison; Is checks whether or not the errors are, fundamentally, the same
 // error. For type tensors.Error, Is checks individual variables (eg. ErrZeroDims != ErrZeroPoint),
 // and for other types (eg. DimsValueError and LengthMismatchError) Is performs a type comparison.
 //
-// Is uses reflect, so it should only be run when an error has actually occurred.
+// Wrapped errors (eg. from OpError) are unwrapped, as with errors.Is, which gives the same results
+// for errors from this package. Errors of other types are compared by type, using reflect, so Is
+// should only be run when an error has actually occurred.
 func Is(err, base error) bool {
-	if e0, ok := base.(Error); ok {
-		if e1, ok := err.(Error); ok {
-			return e0 == e1
-		}
-
+	if errors.Is(err, base) {
+		return true
+	} else if _, ok := base.(Error); ok {
 		return false
 	}
 
@@ -72,4 +322,32 @@ var (
 	ErrChangeTooBig   = Error{"magnitude of change is greater than Interpreter Size"}
 	ErrPointOutOfSync = Error{"increasing point failed while index was within bounds"}
 	ErrNilFunction    = Error{"given MapApply function is nil"}
+	ErrZeroStep       = Error{"step is zero"}
+	ErrEmptyRange     = Error{"range contains no values"}
+	ErrNonFinite      = Error{"range bounds or step are not finite"}
+	ErrNestedType     = Error{"nested value is not made of slices of float64"}
+	ErrNilSampler     = Error{"given Sampler is nil"}
+	ErrNilRand        = Error{"given *rand.Rand is nil"}
+	ErrNoTensors      = Error{"no Tensors were given"}
+	ErrSplitSizes     = Error{"split sizes do not sum to the size of the axis"}
+	ErrChunkCount     = Error{"number of chunks is not within the size of the axis"}
+	ErrEmptySelection = Error{"mask did not select any values"}
+	ErrConvOptions    = Error{"convolution stride or dilation < 1, or padding < 0"}
+	ErrConvGroups     = Error{"channels or filters are not divisible by the number of groups"}
+	ErrConvOutputSize = Error{"kernel is larger than the padded input"}
+	ErrPoolOptions    = Error{"pooling kernel or stride < 1, or padding < 0 or > half of kernel"}
+	ErrPoolOutputSize = Error{"pooling kernel is larger than the padded input"}
+	ErrDuplicateAxis  = Error{"axis was given more than once"}
+	ErrPadMode        = Error{"unknown padding mode"}
+	ErrTapeMismatch   = Error{"Variables are not from the same Tape"}
+	ErrNotScalar      = Error{"Variable has more than one value"}
+	ErrNilGradFunc    = Error{"given GradCheck function is nil"}
+	ErrGradOptions    = Error{"GradCheck eps ≤ 0 or tol < 0"}
+	ErrReduction      = Error{"unknown loss reduction"}
+	ErrMissingGrad    = Error{"parameter has no gradient"}
+	ErrMissingParam   = Error{"gradient has no parameter"}
+	ErrBadSizes       = Error{"Interpreter Sizes do not match its Dims"}
+	ErrBadLayout      = Error{"unknown Interpreter Layout"}
+	ErrNotColumnMajor = Error{"operation requires the ColumnMajor Layout"}
+	ErrLayoutMismatch = Error{"operands have the same Dims but different Layouts"}
 )
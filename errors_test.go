@@ -1,6 +1,9 @@
 package tensors
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 // tests the equality of every type of error that is returned by tensors, to ensure that they are
 // all recognized as unique
@@ -9,6 +12,13 @@ func TestTypedErrors(t *testing.T) {
 		DimsValueError{},
 		LengthMismatchError{},
 		PointOutOfBoundsError{},
+		RaggedError{},
+		AxisError{},
+		ShapeMismatchError{},
+		OverflowError{},
+		LimitError{},
+		ParamError{},
+		OpError{},
 
 		ErrZeroDims,
 		ErrZeroPoint,
@@ -17,6 +27,34 @@ func TestTypedErrors(t *testing.T) {
 		ErrChangeTooBig,
 		ErrPointOutOfSync,
 		ErrNilFunction,
+		ErrZeroStep,
+		ErrEmptyRange,
+		ErrNonFinite,
+		ErrNestedType,
+		ErrNilSampler,
+		ErrNilRand,
+		ErrNoTensors,
+		ErrSplitSizes,
+		ErrChunkCount,
+		ErrEmptySelection,
+		ErrConvOptions,
+		ErrConvGroups,
+		ErrConvOutputSize,
+		ErrPoolOptions,
+		ErrPoolOutputSize,
+		ErrDuplicateAxis,
+		ErrPadMode,
+		ErrTapeMismatch,
+		ErrNotScalar,
+		ErrNilGradFunc,
+		ErrGradOptions,
+		ErrReduction,
+		ErrMissingGrad,
+		ErrMissingParam,
+		ErrBadSizes,
+		ErrBadLayout,
+		ErrNotColumnMajor,
+		ErrLayoutMismatch,
 	}
 
 	for i := range errs {
@@ -28,3 +66,96 @@ func TestTypedErrors(t *testing.T) {
 		}
 	}
 }
+
+// tests that errors wrapped by OpError are identified by Is, errors.Is and errors.As, and that the
+// wrapping adds the name and operands of the operation
+func TestWrappedErrors(t *testing.T) {
+	a, b := NewTensor([]int{2, 3}), NewTensor([]int{3, 4})
+	_, err := MatMulSafe(a, b)
+
+	expected := "MatMul: operand dims [[2 3] [3 4]]: operand 1 dims [3 4] do not match [3 2] along axis 1"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Bad error message. Expected %q, Got %q.", expected, err)
+	}
+
+	if !Is(err, ShapeMismatchError{}) || !errors.Is(err, ShapeMismatchError{}) {
+		t.Errorf("Wrapped ShapeMismatchError was not identified. Got %q.", err)
+	} else if !Is(err, OpError{}) || !errors.Is(err, OpError{}) {
+		t.Errorf("OpError was not identified. Got %q.", err)
+	} else if Is(err, LengthMismatchError{}) || errors.Is(err, LengthMismatchError{}) {
+		t.Errorf("Wrapped error was identified as the wrong type. Got %q.", err)
+	}
+
+	var op OpError
+	if !errors.As(err, &op) || op.Op() != "MatMul" || len(op.Dims()) != 2 {
+		t.Errorf("Bad OpError. Got %q.", err)
+	}
+
+	var e ShapeMismatchError
+	if !errors.As(err, &e) || e.Operand() != 1 || e.Axis() != 1 {
+		t.Errorf("Bad wrapped ShapeMismatchError. Got %q.", err)
+	}
+
+	// errors that are not wrapped are still identified by errors.Is, by type or by value
+	_, err = NewInterpreterSafe([]int{2, 0})
+	var d DimsValueError
+	if !errors.Is(err, DimsValueError{}) || !errors.As(err, &d) || d.Index() != 1 {
+		t.Errorf("Bad DimsValueError. Got %q.", err)
+	}
+
+	_, err = NewInterpreterSafe(nil)
+	if !errors.Is(err, ErrZeroDims) || errors.Is(err, ErrZeroPoint) {
+		t.Errorf("Bad identification of ErrZeroDims. Got %q.", err)
+	}
+
+	err = NewSGD(0.1, 0, false).StepSafe(map[string]Tensor{"w": a}, map[string]Tensor{})
+	var p ParamError
+	if !errors.Is(err, ErrMissingGrad) || !errors.As(err, &p) || p.Name() != "w" {
+		t.Errorf("Bad ParamError. Got %q.", err)
+	}
+}
+
+func TestOpErrors(t *testing.T) {
+	a, b := NewTensor([]int{2, 3}), NewTensor([]int{3, 4})
+	c := NewCOO([]int{2, 3}, nil, nil)
+	idx := NewIndices([]int{2, 3}, make([]int, 6))
+
+	tests := []struct {
+		op  string
+		err func() error
+	}{
+		{"Gather", func() error { _, err := b.GatherSafe(2, idx); return err }},
+		{"Scatter", func() error { return b.ScatterSafe(0, idx, b) }},
+		{"Where", func() error { _, err := WhereSafe(a, a, b); return err }},
+		{"Equal", func() error { _, err := a.EqualSafe(b); return err }},
+		{"Conv", func() error { _, err := ConvSafe(a, b, nil); return err }},
+		{"MaxPool", func() error { _, _, err := a.MaxPoolSafe([]int{1}, nil); return err }},
+		{"Pad", func() error { _, err := PadSafe(a, []int{1}, []int{1}, PadConstant); return err }},
+		{"Crop", func() error { _, err := CropSafe(a, []int{2, 0}, []int{1, 0}); return err }},
+		{"MSE", func() error { _, err := MSESafe(a, b, ReduceMean); return err }},
+		{"CrossEntropyGrad", func() error { _, err := CrossEntropyGradSafe(a, b, 0, ReduceMean); return err }},
+		{"Split", func() error { _, err := a.SplitSafe(2, []int{1}); return err }},
+		{"Chunk", func() error { _, err := a.ChunkSafe(5, 1); return err }},
+		{"CopyFrom", func() error { return a.CopyFromSafe(b) }},
+		{"SetRegion", func() error { return a.SetRegionSafe([]int{0, 0}, b) }},
+		{"FFT", func() error { _, err := a.FFTSafe(2); return err }},
+		{"FFTConvolve", func() error { _, err := FFTConvolveSafe(a, b, 0); return err }},
+		{"COO.Add", func() error { _, err := c.AddSafe(NewCOO([]int{3, 2}, nil, nil)); return err }},
+		{"Softmax", func() error { _, err := a.SoftmaxSafe(2); return err }},
+		{"LogSoftmax", func() error { _, err := a.LogSoftmaxSafe(2); return err }},
+		{"Concat", func() error { _, err := ConcatSafe(0); return err }},
+	}
+
+	for _, test := range tests {
+		err := test.err()
+
+		var op OpError
+		if !errors.As(err, &op) {
+			t.Errorf("%s: error was not wrapped in an OpError. Got %q.", test.op, err)
+		} else if op.Op() != test.op {
+			t.Errorf("%s: bad operation name. Got %q.", test.op, op.Op())
+		} else if errors.As(op.Unwrap(), &op) {
+			t.Errorf("%s: OpError was wrapped twice. Got %q.", test.op, err)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package tensors
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// maxRadix is the largest prime factor that the mixed-radix FFT handles directly. Lengths with
+// larger prime factors are transformed with Bluestein's algorithm instead.
+const maxRadix = 13
+
+// fftPlan stores the precomputed values needed to transform sequences of a single length.
+type fftPlan struct {
+	n int
+
+	// factors are the radices used for each level of recursion. factors is nil if Bluestein's
+	// algorithm is used.
+	factors  []int
+	twiddles []complex128
+
+	// scratch space, for the input to the recursion and for each butterfly
+	buf, tmp []complex128
+
+	// for Bluestein's algorithm: the chirp exp(-πi·j²/n), the transformed convolution kernel, and
+	// the power-of-two plan used for the convolution
+	chirp  []complex128
+	kernel []complex128
+	sub    *fftPlan
+}
+
+// newFFTPlan returns a plan for transforming sequences of length n
+func newFFTPlan(n int) *fftPlan {
+	p := &fftPlan{n: n}
+
+	largest := 1
+	for m, f := n, 2; m > 1; {
+		if f*f > m {
+			f = m
+		}
+
+		if m%f == 0 {
+			p.factors = append(p.factors, f)
+			if f > largest {
+				largest = f
+			}
+			m /= f
+		} else {
+			f++
+		}
+	}
+
+	if largest > maxRadix {
+		p.factors = nil
+		p.initBluestein()
+		return p
+	}
+
+	p.twiddles = make([]complex128, n)
+	for k := range p.twiddles {
+		p.twiddles[k] = cmplx.Rect(1, -2*math.Pi*float64(k)/float64(n))
+	}
+
+	p.buf = make([]complex128, n)
+	p.tmp = make([]complex128, largest)
+	return p
+}
+
+func (p *fftPlan) initBluestein() {
+	n := p.n
+
+	m := 1
+	for m < 2*n-1 {
+		m *= 2
+	}
+
+	p.sub = newFFTPlan(m)
+	p.chirp = make([]complex128, n)
+	p.kernel = make([]complex128, m)
+	p.buf = make([]complex128, m)
+
+	for j := range p.chirp {
+		// j² is reduced modulo 2n to keep the angle small, and therefore precise
+		k := (j * j) % (2 * n)
+		p.chirp[j] = cmplx.Rect(1, -math.Pi*float64(k)/float64(n))
+
+		p.kernel[j] = cmplx.Conj(p.chirp[j])
+		if j != 0 {
+			p.kernel[m-j] = p.kernel[j]
+		}
+	}
+
+	p.sub.transform(p.kernel)
+}
+
+// transform replaces x (with length n) with its discrete Fourier transform.
+func (p *fftPlan) transform(x []complex128) {
+	if p.sub != nil {
+		p.bluestein(x)
+		return
+	} else if p.n == 1 {
+		return
+	}
+
+	copy(p.buf, x)
+	p.recurse(x, p.buf, 1, p.n, p.factors)
+}
+
+// inverse replaces x (with length n) with its inverse discrete Fourier transform.
+func (p *fftPlan) inverse(x []complex128) {
+	for i := range x {
+		x[i] = cmplx.Conj(x[i])
+	}
+
+	p.transform(x)
+
+	scale := complex(1/float64(p.n), 0)
+	for i := range x {
+		x[i] = cmplx.Conj(x[i]) * scale
+	}
+}
+
+// recurse performs a mixed-radix decimation-in-time transform of the n values in src with the
+// given stride, writing the result to dst.
+func (p *fftPlan) recurse(dst, src []complex128, stride, n int, factors []int) {
+	if n == 1 {
+		dst[0] = src[0]
+		return
+	}
+
+	r := factors[0]
+	m := n / r
+	for j := 0; j < r; j++ {
+		p.recurse(dst[j*m:(j+1)*m], src[j*stride:], stride*r, m, factors[1:])
+	}
+
+	// twiddles are stored for length p.n; step converts them to length n
+	step := p.n / n
+	for k := 0; k < m; k++ {
+		for q := 0; q < r; q++ {
+			var sum complex128
+			for j := 0; j < r; j++ {
+				sum += dst[j*m+k] * p.twiddles[(j*(q*m+k))%n*step]
+			}
+
+			p.tmp[q] = sum
+		}
+
+		for q := 0; q < r; q++ {
+			dst[q*m+k] = p.tmp[q]
+		}
+	}
+}
+
+// bluestein computes the transform of x as a convolution with a chirp, which can be performed
+// with a power-of-two FFT.
+func (p *fftPlan) bluestein(x []complex128) {
+	a := p.buf
+	for i := range a {
+		a[i] = 0
+	}
+
+	for j, v := range x {
+		a[j] = v * p.chirp[j]
+	}
+
+	p.sub.transform(a)
+	for i := range a {
+		a[i] *= p.kernel[i]
+	}
+	p.sub.inverse(a)
+
+	for k := range x {
+		x[k] = a[k] * p.chirp[k]
+	}
+}
+
+// checkLanes returns ErrNotColumnMajor if the Interpreter is not ColumnMajor, an AxisError if axis
+// is out of bounds, or a DimsValueError if any dimension of the Interpreter is zero, as may be
+// given by NewInterpreterExtended. The Fourier transforms do not support Tensors with no values.
+func (in Interpreter) checkLanes(axis int) error {
+	if err := checkLayout(in); err != nil {
+		return err
+	} else if err := in.checkAxis(axis); err != nil {
+		return err
+	}
+
+	for i, d := range in.Dims {
+		if d == 0 {
+			return DimsValueError{in.Dims, i}
+		}
+	}
+
+	return nil
+}
+
+// transformLanes returns the result of transforming every lane of c along the given axis, which
+// must be valid.
+func (c ComplexTensor) transformLanes(axis int, inverse bool) ComplexTensor {
+	out := ComplexTensor{c.Interpreter, make([]complex128, len(c.Values))}
+
+	n := c.Dims[axis]
+	p := newFFTPlan(n)
+	lane := make([]complex128, n)
+
+	c.eachLane(axis, func(start, stride, n int) {
+		for i := range lane {
+			lane[i] = c.Values[start+i*stride]
+		}
+
+		if inverse {
+			p.inverse(lane)
+		} else {
+			p.transform(lane)
+		}
+
+		for i, v := range lane {
+			out.Values[start+i*stride] = v
+		}
+	})
+
+	return out
+}
+
+// FFT returns the discrete Fourier transform of each lane of c along the given axis. Lengths whose
+// prime factors are all small are transformed with a mixed-radix Cooley-Tukey algorithm; others
+// use Bluestein's algorithm. Either way, the transform takes O(n log n) time.
+//
+// FFT will panic with an AxisError if axis is out of bounds, or a DimsValueError if any dimension
+// of c is zero. FFTSafe returns these errors instead.
+func (c ComplexTensor) FFT(axis int) ComplexTensor {
+	f, err := c.FFTSafe(axis)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// FFTSafe undergoes the same process as FFT, but returns error instead of panicking.
+func (c ComplexTensor) FFTSafe(axis int) (ComplexTensor, error) {
+	if err := c.checkLanes(axis); err != nil {
+		return ComplexTensor{}, opError("ComplexTensor.FFT", err, c.Interpreter)
+	}
+
+	return c.transformLanes(axis, false), nil
+}
+
+// IFFT returns the inverse discrete Fourier transform of each lane of c along the given axis,
+// scaled by 1/n so that c.FFT(axis).IFFT(axis) returns the original values.
+//
+// IFFT will panic under the same conditions as FFT. IFFTSafe returns these errors instead.
+func (c ComplexTensor) IFFT(axis int) ComplexTensor {
+	f, err := c.IFFTSafe(axis)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// IFFTSafe undergoes the same process as IFFT, but returns error instead of panicking.
+func (c ComplexTensor) IFFTSafe(axis int) (ComplexTensor, error) {
+	if err := c.checkLanes(axis); err != nil {
+		return ComplexTensor{}, opError("ComplexTensor.IFFT", err, c.Interpreter)
+	}
+
+	return c.transformLanes(axis, true), nil
+}
+
+// FFT returns the discrete Fourier transform of each lane of t along the given axis. For more
+// information, see ComplexTensor.FFT.
+func (t Tensor) FFT(axis int) ComplexTensor {
+	return t.Complex().FFT(axis)
+}
+
+// FFTSafe undergoes the same process as FFT, but returns error instead of panicking.
+func (t Tensor) FFTSafe(axis int) (ComplexTensor, error) {
+	res, err := t.Complex().FFTSafe(axis)
+	return res, opError("FFT", err, t.Interpreter)
+}
+
+// RFFT returns the non-negative frequency terms of the discrete Fourier transform of each lane of
+// t along the given axis. Because the input is real, the remaining terms are the complex
+// conjugates of these, and are omitted: if the axis has length n, the result has length n/2+1
+// along it.
+//
+// RFFT will panic under the same conditions as FFT. RFFTSafe returns these errors instead.
+func (t Tensor) RFFT(axis int) ComplexTensor {
+	f, err := t.RFFTSafe(axis)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// RFFTSafe undergoes the same process as RFFT, but returns error instead of panicking.
+func (t Tensor) RFFTSafe(axis int) (ComplexTensor, error) {
+	f, err := t.FFTSafe(axis)
+	if err != nil {
+		return ComplexTensor{}, opError("RFFT", err, t.Interpreter)
+	}
+
+	dims := make([]int, len(f.Dims))
+	copy(dims, f.Dims)
+	dims[axis] = dims[axis]/2 + 1
+
+	out := NewComplexTensor(dims)
+	f.eachLane(axis, func(start, stride, n int) {
+		// lanes of out have the same starting position in the outer loop, adjusted for length
+		outer := start / (n * stride)
+		inner := start % stride
+		base := outer*dims[axis]*stride + inner
+
+		for i := 0; i < dims[axis]; i++ {
+			out.Values[base+i*stride] = f.Values[start+i*stride]
+		}
+	})
+
+	return out, nil
+}
+
+// IRFFT is the inverse of RFFT, returning the real signal of length n along the given axis whose
+// non-negative frequency terms are given by c. Along the axis, c must have length n/2+1. Any
+// imaginary parts of the zero (and, if n is even, the n/2) frequency terms are ignored.
+//
+// IRFFT will panic if any of the error conditions from IRFFTSafe are met.
+func (c ComplexTensor) IRFFT(axis, n int) Tensor {
+	t, err := c.IRFFTSafe(axis, n)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// IRFFTSafe undergoes the same process as IRFFT, but returns error instead of panicking.
+// IRFFTSafe returns an AxisError if axis is out of bounds, a DimsValueError if any dimension of c
+// is zero, and a LengthMismatchError if n is less than 1 or the length of c along the axis is not
+// n/2+1.
+func (c ComplexTensor) IRFFTSafe(axis, n int) (Tensor, error) {
+	if err := c.checkLanes(axis); err != nil {
+		return Tensor{}, opError("ComplexTensor.IRFFT", err, c.Interpreter)
+	} else if n < 1 || c.Dims[axis] != n/2+1 {
+		return Tensor{}, opError("ComplexTensor.IRFFT",
+			LengthMismatchError{"irfft terms", c.Dims[axis], n/2 + 1}, c.Interpreter)
+	}
+
+	dims := make([]int, len(c.Dims))
+	copy(dims, c.Dims)
+	dims[axis] = n
+
+	out := NewTensor(dims)
+	p := newFFTPlan(n)
+	lane := make([]complex128, n)
+	terms := c.Dims[axis]
+
+	out.eachLane(axis, func(start, stride, _ int) {
+		outer := start / (n * stride)
+		inner := start % stride
+		base := outer*terms*stride + inner
+
+		lane[0] = complex(real(c.Values[base]), 0)
+		for k := 1; k < terms; k++ {
+			lane[k] = c.Values[base+k*stride]
+			lane[n-k] = cmplx.Conj(lane[k])
+		}
+
+		if n%2 == 0 {
+			lane[n/2] = complex(real(lane[n/2]), 0)
+		}
+
+		p.inverse(lane)
+		for i, v := range lane {
+			out.Values[start+i*stride] = real(v)
+		}
+	})
+
+	return out, nil
+}
+
+// FFTConvolve returns the full linear convolution of each lane of t along the given axis with the
+// one-dimensional kernel, computed with FFTs. This is much faster than direct convolution for long
+// kernels. If the axis has length n and the kernel length k, the result has length n+k-1 along the
+// axis. Note that, unlike Conv, this is a true convolution: the kernel is reversed.
+//
+// FFTConvolve will panic if any of the error conditions from FFTConvolveSafe are met.
+func FFTConvolve(t, kernel Tensor, axis int) Tensor {
+	return must(FFTConvolveSafe(t, kernel, axis))
+}
+
+// FFTConvolveSafe undergoes the same process as FFTConvolve, but returns error instead of
+// panicking. FFTConvolveSafe returns an AxisError if axis is out of bounds, a LengthMismatchError
+// if the kernel does not have exactly one dimension, and a DimsValueError if any dimension of t or
+// the kernel is zero.
+func FFTConvolveSafe(t, kernel Tensor, axis int) (Tensor, error) {
+	if err := t.checkLanes(axis); err != nil {
+		return Tensor{}, opError("FFTConvolve", err, t.Interpreter, kernel.Interpreter)
+	} else if len(kernel.Dims) != 1 {
+		err := LengthMismatchError{"kernel dims", len(kernel.Dims), 1}
+		return Tensor{}, opError("FFTConvolve", err, t.Interpreter, kernel.Interpreter)
+	} else if err := kernel.checkLanes(0); err != nil {
+		return Tensor{}, opError("FFTConvolve", err, t.Interpreter, kernel.Interpreter)
+	}
+
+	n, k := t.Dims[axis], kernel.Dims[0]
+	length := n + k - 1
+
+	// a power of two is not required, but is the fastest length to transform
+	size := 1
+	for size < length {
+		size *= 2
+	}
+
+	p := newFFTPlan(size)
+
+	kf := make([]complex128, size)
+	for i, v := range kernel.Values {
+		kf[i] = complex(v, 0)
+	}
+	p.transform(kf)
+
+	dims := make([]int, len(t.Dims))
+	copy(dims, t.Dims)
+	dims[axis] = length
+
+	out := NewTensor(dims)
+	lane := make([]complex128, size)
+
+	t.eachLane(axis, func(start, stride, _ int) {
+		for i := range lane {
+			lane[i] = 0
+		}
+		for i := 0; i < n; i++ {
+			lane[i] = complex(t.Values[start+i*stride], 0)
+		}
+
+		p.transform(lane)
+		for i := range lane {
+			lane[i] *= kf[i]
+		}
+		p.inverse(lane)
+
+		outer := start / (n * stride)
+		inner := start % stride
+		base := outer*length*stride + inner
+		for i := 0; i < length; i++ {
+			out.Values[base+i*stride] = real(lane[i])
+		}
+	})
+
+	return out, nil
+}
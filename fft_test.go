@@ -0,0 +1,156 @@
+package tensors
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// naiveDFT returns the discrete Fourier transform of x, computed directly from the definition
+func naiveDFT(x []complex128) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := range out {
+		for j, v := range x {
+			out[k] += v * cmplx.Rect(1, -2*math.Pi*float64(j*k%n)/float64(n))
+		}
+	}
+
+	return out
+}
+
+func complexApproxEqual(a, b ComplexTensor, tol float64) bool {
+	if !Equals(a.Interpreter, b.Interpreter) {
+		return false
+	}
+
+	for i := range a.Values {
+		if !(cmplx.Abs(a.Values[i]-b.Values[i]) <= tol) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// requires Random
+func tFFT(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+
+	// lengths covering each radix, mixed radices, and Bluestein's algorithm
+	for _, n := range []int{1, 2, 3, 4, 5, 6, 7, 8, 12, 13, 17, 30, 31, 64, 97} {
+		x := NewRandom([]int{n}, Uniform(-1, 1), rng).Complex()
+		expected := ComplexTensor{x.Interpreter, naiveDFT(x.Values)}
+
+		f, err := x.FFTSafe(0)
+		if !handleErrors(t, "FFT", nil, err, "N: %d.", n) {
+			continue
+		}
+
+		if !complexApproxEqual(expected, f, 1e-9) {
+			t.Errorf("FFT: Bad values. N: %d. Expected %v, Got %v.", n, expected.Values, f.Values)
+		}
+
+		if inv := f.IFFT(0); !complexApproxEqual(x, inv, 1e-12) {
+			t.Errorf("IFFT: Bad values. N: %d. Expected %v, Got %v.", n, x.Values, inv.Values)
+		}
+	}
+
+	// transforming along each axis of a larger Tensor
+	x := NewRandom([]int{3, 5, 4}, Uniform(-1, 1), rng)
+	for axis := 0; axis < 3; axis++ {
+		f := x.FFT(axis)
+
+		lane := make([]complex128, x.Dims[axis])
+		x.eachLane(axis, func(start, stride, n int) {
+			for i := range lane {
+				lane[i] = complex(x.Values[start+i*stride], 0)
+			}
+
+			for i, v := range naiveDFT(lane) {
+				if cmplx.Abs(f.Values[start+i*stride]-v) > 1e-12 {
+					t.Errorf("FFT: Bad value. Axis: %d, Index: %d. Expected %v, Got %v.",
+						axis, start+i*stride, v, f.Values[start+i*stride])
+				}
+			}
+		})
+	}
+
+	_, err := x.FFTSafe(3)
+	handleErrors(t, "FFT", AxisError{}, err, "Axis: 3.")
+}
+
+// requires FFT
+func tRFFT(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	for _, n := range []int{1, 2, 5, 8, 19} {
+		x := NewRandom([]int{2, n, 3}, Uniform(-1, 1), rng)
+
+		r, err := x.RFFTSafe(1)
+		if !handleErrors(t, "RFFT", nil, err, "N: %d.", n) {
+			continue
+		}
+
+		handleReturn(t, "RFFT", []int{2, n/2 + 1, 3}, r.Dims, "N: %d.", n)
+
+		f := x.FFT(1)
+		for i := 0; i < 2; i++ {
+			for k := 0; k <= n/2; k++ {
+				for j := 0; j < 3; j++ {
+					e, g := f.Values[f.IndexFast([]int{i, k, j})], r.Values[r.IndexFast([]int{i, k, j})]
+					if e != g {
+						t.Errorf("RFFT: Bad value. N: %d, Point: %v. Expected %v, Got %v.",
+							n, []int{i, k, j}, e, g)
+					}
+				}
+			}
+		}
+
+		inv, err := r.IRFFTSafe(1, n)
+		if handleErrors(t, "IRFFT", nil, err, "N: %d.", n) && !approxEqual(x, inv, 1e-12) {
+			t.Errorf("IRFFT: Bad values. N: %d. Expected %v, Got %v.", n, x, inv)
+		}
+	}
+
+	_, err := NewComplexTensor([]int{4}).IRFFTSafe(0, 8)
+	handleErrors(t, "IRFFT", LengthMismatchError{}, err, "N: 8.")
+
+	_, err = NewTensorExtended([]int{0, 3}).RFFTSafe(1)
+	handleErrors(t, "RFFT", DimsValueError{}, err, "Empty.")
+}
+
+// requires FFT
+func tFFTConvolve(t *testing.T) {
+	rng := rand.New(rand.NewSource(8))
+	x := NewRandom([]int{3, 20, 2}, Uniform(-1, 1), rng)
+	kernel := NewRandom([]int{7}, Uniform(-1, 1), rng)
+
+	res, err := FFTConvolveSafe(x, kernel, 1)
+	if !handleErrors(t, "FFTConvolve", nil, err, "") {
+		return
+	}
+
+	expected := NewTensor([]int{3, 26, 2})
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2; j++ {
+			for a := 0; a < 20; a++ {
+				for b := 0; b < 7; b++ {
+					expected.Values[expected.IndexFast([]int{i, a + b, j})] +=
+						x.Values[x.IndexFast([]int{i, a, j})] * kernel.Values[b]
+				}
+			}
+		}
+	}
+
+	if !approxEqual(expected, res, 1e-12) {
+		t.Errorf("FFTConvolve: Bad values. Expected %v, Got %v.", expected, res)
+	}
+
+	_, err = FFTConvolveSafe(x, x, 1)
+	handleErrors(t, "FFTConvolve", LengthMismatchError{}, err, "2-D kernel.")
+
+	_, err = FFTConvolveSafe(x, kernel, -1)
+	handleErrors(t, "FFTConvolve", AxisError{}, err, "Axis: -1.")
+}
@@ -0,0 +1,168 @@
+package tensors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// summarizeThreshold is the number of values above which a Tensor will be summarized when
+	// printed, instead of printing every value.
+	summarizeThreshold = 1000
+
+	// edgeItems is the number of values that are printed at each end of a summarized dimension.
+	edgeItems = 3
+)
+
+// Format implements fmt.Formatter, printing the values of the Tensor as nested brackets, one
+// level for each dimension. The outermost brackets correspond to the highest dimension, so the
+// innermost rows contain values along Dims[0] -- the same order that they are stored in Values.
+// Values in every row are right-aligned into columns.
+//
+// Format supports the verbs 'v', 's', 'e', 'E', 'f', 'F', 'g' and 'G'. 'v' and 's' behave as 'g'
+// does. Precision is handled as it would be for a single float64; eg. "%.3v" prints each value
+// with three significant digits, and "%.2f" prints each value with two decimal places. Width sets
+// the minimum width of each individual value, not of the whole Tensor.
+//
+// If the Tensor has more than 1000 values, dimensions with more than 6 values are summarized,
+// printing only the first and last 3, separated by an ellipsis.
+//
+// A rank-0 Tensor (from NewTensorExtended or Scalar) is printed as its single value, and a Tensor
+// with no values as empty brackets.
+//
+// With the '+' flag (eg. "%+v"), the dimensions of the Tensor are printed on a line before the
+// values, along with its Layout if it is not ColumnMajor. The values themselves are printed in the
+// same arrangement regardless of Layout.
+func (t Tensor) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		verb = 'g'
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+	default:
+		fmt.Fprintf(f, "%%!%c(tensors.Tensor=%v)", verb, t.Dims)
+		return
+	}
+
+	if f.Flag('+') && t.Layout != ColumnMajor {
+		fmt.Fprintf(f, "Tensor(dims=%v, layout=%v)\n", t.Dims, t.Layout)
+	} else if f.Flag('+') {
+		fmt.Fprintf(f, "Tensor(dims=%v)\n", t.Dims)
+	}
+
+	// A zero-valued Tensor has no Interpreter to index with
+	if len(t.Sizes) == 0 || len(t.Values) != t.Size() {
+		f.Write([]byte("[]"))
+		return
+	}
+
+	prec := -1
+	if p, ok := f.Precision(); ok {
+		prec = p
+	}
+
+	// a rank-0 Tensor is printed as its single value, without brackets
+	if len(t.Dims) == 0 {
+		s := strconv.FormatFloat(t.Values[0], byte(verb), prec, 64)
+		if width, _ := f.Width(); f.Flag('-') && width > len(s) {
+			s += strings.Repeat(" ", width-len(s))
+		} else if width > len(s) {
+			s = strings.Repeat(" ", width-len(s)) + s
+		}
+
+		f.Write([]byte(s))
+		return
+	}
+
+	// the indices that will be displayed for each dimension, with -1 marking an ellipsis
+	shown := make([][]int, len(t.Dims))
+	summarize := t.Size() > summarizeThreshold
+	for i, d := range t.Dims {
+		if summarize && d > 2*edgeItems {
+			for j := 0; j < edgeItems; j++ {
+				shown[i] = append(shown[i], j)
+			}
+			shown[i] = append(shown[i], -1)
+			for j := d - edgeItems; j < d; j++ {
+				shown[i] = append(shown[i], j)
+			}
+		} else {
+			shown[i] = make([]int, d)
+			for j := range shown[i] {
+				shown[i][j] = j
+			}
+		}
+	}
+
+	// format each displayed value ahead of time, so that we know how wide the columns must be
+	strs := make(map[int]string)
+	width, _ := f.Width()
+
+	var collect func(axis, base int)
+	collect = func(axis, base int) {
+		for _, i := range shown[axis] {
+			if i == -1 {
+				continue
+			}
+
+			index := base + i*t.stride(axis)
+			if axis != 0 {
+				collect(axis-1, index)
+				continue
+			}
+
+			s := strconv.FormatFloat(t.Values[index], byte(verb), prec, 64)
+			strs[index] = s
+			if len(s) > width {
+				width = len(s)
+			}
+		}
+	}
+
+	collect(len(t.Dims)-1, 0)
+
+	var b strings.Builder
+
+	var write func(axis, base, depth int)
+	write = func(axis, base, depth int) {
+		b.WriteByte('[')
+		for n, i := range shown[axis] {
+			if n != 0 {
+				if axis == 0 {
+					b.WriteByte(' ')
+				} else {
+					b.WriteString(strings.Repeat("\n", axis))
+					b.WriteString(strings.Repeat(" ", depth+1))
+				}
+			}
+
+			if i == -1 {
+				b.WriteString("...")
+				continue
+			}
+
+			index := base + i*t.stride(axis)
+			if axis != 0 {
+				write(axis-1, index, depth+1)
+				continue
+			}
+
+			s := strs[index]
+			if f.Flag('-') {
+				b.WriteString(s + strings.Repeat(" ", width-len(s)))
+			} else {
+				b.WriteString(strings.Repeat(" ", width-len(s)) + s)
+			}
+		}
+		b.WriteByte(']')
+	}
+
+	write(len(t.Dims)-1, 0, 0)
+	f.Write([]byte(b.String()))
+}
+
+// String returns the Tensor formatted with the verb "%v". For more information, see the
+// documentation for Tensor.Format.
+func (t Tensor) String() string {
+	return fmt.Sprint(t)
+}
@@ -0,0 +1,45 @@
+package tensors
+
+import (
+	"fmt"
+	"testing"
+)
+
+// requires NewInterpreter
+func tFormat(t *testing.T) {
+	matrix := NewTensor([]int{3, 2})
+	copy(matrix.Values, []float64{1, 2, 3, 40, 5, 6})
+
+	cube := NewTensor([]int{2, 2, 2})
+	copy(cube.Values, []float64{0, 1, 2, 3, 4, 5, 6, 7})
+
+	big := NewTensor([]int{10, 200})
+
+	table := []struct {
+		format string
+		t      Tensor
+		out    string
+	}{
+		{"%v", Tensor{}, "[]"},
+		{"%v", Scalar(2.5), "2.5"},
+		{"%4v", Scalar(2), "   2"},
+		{"%+v", Scalar(1), "Tensor(dims=[])\n1"},
+		{"%v", NewTensorExtended([]int{0, 2}), "[[]\n []]"},
+		{"%v", matrix, "[[ 1  2  3]\n [40  5  6]]"},
+		{"%.1f", matrix, "[[ 1.0  2.0  3.0]\n [40.0  5.0  6.0]]"},
+		{"%.2v", matrix, "[[ 1  2  3]\n [40  5  6]]"},
+		{"%3v", matrix, "[[  1   2   3]\n [ 40   5   6]]"},
+		{"%-v", matrix, "[[1  2  3 ]\n [40 5  6 ]]"},
+		{"%+v", matrix, "Tensor(dims=[3 2])\n[[ 1  2  3]\n [40  5  6]]"},
+		{"%v", cube, "[[[0 1]\n  [2 3]]\n\n [[4 5]\n  [6 7]]]"},
+		{"%d", matrix, "%!d(tensors.Tensor=[3 2])"},
+		{"%v", big, "[[0 0 0 ... 0 0 0]\n [0 0 0 ... 0 0 0]\n [0 0 0 ... 0 0 0]\n ...\n" +
+			" [0 0 0 ... 0 0 0]\n [0 0 0 ... 0 0 0]\n [0 0 0 ... 0 0 0]]"},
+	}
+
+	for _, tab := range table {
+		handleReturn(t, "Format", tab.out, fmt.Sprintf(tab.format, tab.t), "Format: %q.", tab.format)
+	}
+
+	handleReturn(t, "String", fmt.Sprint(matrix), matrix.String(), "")
+}
@@ -0,0 +1,249 @@
+package tensors
+
+// Indices is the integer analog to Tensor, used to give positions along an axis to Gather,
+// Scatter and ScatterAdd. Its values are stored in the same order as those of a Tensor.
+type Indices struct {
+	Interpreter
+
+	// Values is the set of positions stored in the Indices. As with Tensor, the description for
+	// the storage of these values can be found in the documentation for Interpreter.Dims
+	Values []int
+}
+
+// NewIndices returns a new Indices with the given dimensions and values. Values is not copied.
+// NewIndices will panic if any of the error conditions from NewIndicesSafe are met.
+func NewIndices(dims []int, values []int) Indices {
+	idx, err := NewIndicesSafe(dims, values)
+	if err != nil {
+		panic(err)
+	}
+
+	return idx
+}
+
+// NewIndicesSafe undergoes the same process as NewIndices, but returns error instead of
+// panicking. In addition to the errors from NewInterpreterSafe, NewIndicesSafe will return a
+// LengthMismatchError if the number of values does not match the size of the dimensions.
+func NewIndicesSafe(dims []int, values []int) (Indices, error) {
+	in, err := NewInterpreterSafe(dims)
+	if err != nil {
+		return Indices{}, err
+	}
+
+	if len(values) != in.Size() {
+		return Indices{}, LengthMismatchError{"values", len(values), in.Size()}
+	}
+
+	return Indices{in, values}, nil
+}
+
+// checkIndices checks that idx is usable along the given axis of the Tensor: it must have the
+// same number of dimensions, and no dimension (other than axis) may be larger than that of the
+// Tensor. operand is used to identify idx in any ShapeMismatchError.
+func (t Tensor) checkIndices(axis int, idx Indices, operand int) error {
+	if err := checkLayout(t.Interpreter, idx.Interpreter); err != nil {
+		return err
+	} else if err := t.checkAxis(axis); err != nil {
+		return err
+	} else if len(idx.Dims) != len(t.Dims) {
+		return ShapeMismatchError{operand, -1, idx.Dims, t.Dims}
+	}
+
+	for i := range t.Dims {
+		if i != axis && idx.Dims[i] > t.Dims[i] {
+			return ShapeMismatchError{operand, i, idx.Dims, t.Dims}
+		}
+	}
+
+	return nil
+}
+
+// scatterGather is the shared iteration for Gather, Scatter and ScatterAdd. For every point of
+// idx, fn is called with the index of that point in idx and the index of the point in t that it
+// refers to -- the same point, but with its value along axis replaced by the value of idx.
+//
+// If any of the values of idx are out of bounds, a PointOutOfBoundsError is returned for the
+// point in t, and fn has already been called for all prior points.
+func (t Tensor) scatterGather(axis int, idx Indices, fn func(i, target int)) error {
+	point := make([]int, len(idx.Dims))
+	target := make([]int, len(idx.Dims))
+
+	for i := range idx.Values {
+		copy(target, point)
+		target[axis] = idx.Values[i]
+
+		if target[axis] < 0 || target[axis] >= t.Dims[axis] {
+			return PointOutOfBoundsError{target, t.Dims, axis}
+		}
+
+		fn(i, t.IndexFast(target))
+		idx.IncrementFast(point)
+	}
+
+	return nil
+}
+
+// Gather returns a new Tensor with the same dimensions as idx, where each value is taken from the
+// Tensor at the same point, except along the given axis, where the position is given by the value
+// of idx at that point. In other words, for a 3-dimensional Tensor with axis 1:
+//		result[i][j][k] = t[i][idx[i][j][k]][k]
+//
+// idx must have the same number of dimensions as the Tensor, and must be no larger along any
+// axis except the given one.
+//
+// Gather will panic with an AxisError if axis is out of bounds, a ShapeMismatchError (naming idx
+// as operand 1) if idx is not compatible with the Tensor, or a PointOutOfBoundsError if any value
+// of idx is out of bounds of Dims[axis]. GatherSafe returns these errors instead.
+func (t Tensor) Gather(axis int, idx Indices) Tensor {
+	res, err := t.GatherSafe(axis, idx)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// GatherSafe undergoes the same process as Gather, but returns error instead of panicking.
+func (t Tensor) GatherSafe(axis int, idx Indices) (Tensor, error) {
+	if err := t.checkIndices(axis, idx, 1); err != nil {
+		return Tensor{}, opError("Gather", err, t.Interpreter, idx.Interpreter)
+	}
+
+	res := Tensor{Interpreter: idx.Interpreter.Clone(), Values: make([]float64, idx.Size())}
+	err := t.scatterGather(axis, idx, func(i, target int) {
+		res.Values[i] = t.Values[target]
+	})
+
+	if err != nil {
+		return Tensor{}, opError("Gather", err, t.Interpreter, idx.Interpreter)
+	}
+
+	return res, nil
+}
+
+// Scatter is the inverse of Gather. It writes the values of src into the Tensor at the points
+// given by idx along the given axis, modifying the Tensor in place. In other words, for a
+// 3-dimensional Tensor with axis 1:
+//		t[i][idx[i][j][k]][k] = src[i][j][k]
+//
+// src must have the same dimensions as idx, which has the same requirements as with Gather. If
+// multiple values are written to the same point, the one with the highest index in src is kept.
+//
+// Scatter will panic under the same conditions as Gather, or with a ShapeMismatchError (naming src
+// as operand 2) if the dimensions of src and idx are not equal. If ScatterSafe returns a
+// PointOutOfBoundsError, values before the offending point in idx will already have been written.
+func (t *Tensor) Scatter(axis int, idx Indices, src Tensor) {
+	if err := t.ScatterSafe(axis, idx, src); err != nil {
+		panic(err)
+	}
+}
+
+// ScatterSafe undergoes the same process as Scatter, but returns error instead of panicking.
+func (t *Tensor) ScatterSafe(axis int, idx Indices, src Tensor) error {
+	if err := t.checkScatter(axis, idx, src); err != nil {
+		return opError("Scatter", err, t.Interpreter, idx.Interpreter, src.Interpreter)
+	}
+
+	t.Unshare()
+	err := t.scatterGather(axis, idx, func(i, target int) {
+		t.Values[target] = src.Values[i]
+	})
+
+	return opError("Scatter", err, t.Interpreter, idx.Interpreter, src.Interpreter)
+}
+
+// ScatterAdd is the same as Scatter, except that values from src are added to those of the
+// Tensor, rather than replacing them. Values written to the same point multiple times accumulate.
+// This makes ScatterAdd the backward pass of Gather: scattering the gradient with respect to the
+// result of Gather onto a zero'd Tensor gives the gradient with respect to the original.
+func (t *Tensor) ScatterAdd(axis int, idx Indices, src Tensor) {
+	if err := t.ScatterAddSafe(axis, idx, src); err != nil {
+		panic(err)
+	}
+}
+
+// ScatterAddSafe undergoes the same process as ScatterAdd, but returns error instead of
+// panicking.
+func (t *Tensor) ScatterAddSafe(axis int, idx Indices, src Tensor) error {
+	if err := t.checkScatter(axis, idx, src); err != nil {
+		return opError("ScatterAdd", err, t.Interpreter, idx.Interpreter, src.Interpreter)
+	}
+
+	t.Unshare()
+	err := t.scatterGather(axis, idx, func(i, target int) {
+		t.Values[target] += src.Values[i]
+	})
+
+	return opError("ScatterAdd", err, t.Interpreter, idx.Interpreter, src.Interpreter)
+}
+
+// checkScatter performs the checks shared by ScatterSafe and ScatterAddSafe.
+func (t Tensor) checkScatter(axis int, idx Indices, src Tensor) error {
+	if err := t.checkIndices(axis, idx, 1); err != nil {
+		return err
+	} else if err := checkLayout(src.Interpreter); err != nil {
+		return err
+	} else if !Equals(idx.Interpreter, src.Interpreter) {
+		return ShapeMismatchError{2, -1, src.Dims, idx.Dims}
+	}
+
+	return nil
+}
+
+// IndexSelect returns a new Tensor made of the slices of the Tensor at the given positions along
+// axis, in order. The result has the same dimensions as the Tensor, except that Dims[axis] is
+// equal to len(indices). Positions may be repeated. For example, with a Tensor of embeddings with
+// Dims [width, vocabulary], IndexSelect(1, tokens) gives the embedding for each token.
+//
+// IndexSelect will panic with an AxisError if axis is out of bounds, a DimsValueError if indices
+// is empty, or a PointOutOfBoundsError if any of the indices are out of bounds of Dims[axis].
+// IndexSelectSafe returns these errors instead.
+func (t Tensor) IndexSelect(axis int, indices []int) Tensor {
+	res, err := t.IndexSelectSafe(axis, indices)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// IndexSelectSafe undergoes the same process as IndexSelect, but returns error instead of
+// panicking.
+func (t Tensor) IndexSelectSafe(axis int, indices []int) (Tensor, error) {
+	if err := checkLayout(t.Interpreter); err != nil {
+		return Tensor{}, opError("IndexSelect", err, t.Interpreter)
+	} else if err := t.checkAxis(axis); err != nil {
+		return Tensor{}, opError("IndexSelect", err, t.Interpreter)
+	}
+
+	dims := make([]int, len(t.Dims))
+	copy(dims, t.Dims)
+	dims[axis] = len(indices)
+
+	res, err := NewTensorSafe(dims)
+	if err != nil {
+		return Tensor{}, opError("IndexSelect", err, t.Interpreter)
+	}
+
+	for _, v := range indices {
+		if v < 0 || v >= t.Dims[axis] {
+			point := make([]int, len(t.Dims))
+			point[axis] = v
+			return Tensor{}, opError("IndexSelect", PointOutOfBoundsError{point, t.Dims, axis},
+				t.Interpreter)
+		}
+	}
+
+	// within each outer block, the slice at each position along axis is contiguous, with length
+	// inner.
+	outer, inner := t.split(axis)
+	index := 0
+	for o := 0; o < outer; o++ {
+		block := t.Values[o*t.Dims[axis]*inner:]
+		for _, v := range indices {
+			index += copy(res.Values[index:index+inner], block[v*inner:])
+		}
+	}
+
+	return res, nil
+}
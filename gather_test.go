@@ -0,0 +1,114 @@
+package tensors
+
+import (
+	"testing"
+)
+
+// requires FromNested
+func tGather(t *testing.T) {
+	// Dims [3, 2]
+	tensor := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	table := []struct {
+		axis int
+		idx  Indices
+		res  Tensor
+		err  error
+	}{
+		{0, NewIndices([]int{2, 2}, []int{2, 0, 1, 1}), FromNested([][]float64{{3, 1}, {5, 5}}), nil},
+		{1, NewIndices([]int{3, 1}, []int{1, 0, 1}), FromNested([][]float64{{4, 2, 6}}), nil},
+		{1, NewIndices([]int{1, 3}, []int{1, 0, 1}), FromNested([][]float64{{4}, {1}, {4}}), nil},
+
+		{2, NewIndices([]int{1, 1}, []int{0}), Tensor{}, AxisError{}},
+		{0, NewIndices([]int{2}, []int{0, 0}), Tensor{}, ShapeMismatchError{}},
+		{1, NewIndices([]int{4, 1}, []int{0, 0, 0, 0}), Tensor{}, ShapeMismatchError{}},
+		{0, NewIndices([]int{1, 2}, []int{0, 3}), Tensor{}, PointOutOfBoundsError{}},
+		{1, NewIndices([]int{1, 1}, []int{-1}), Tensor{}, PointOutOfBoundsError{}},
+	}
+
+	for _, tab := range table {
+		res, err := tensor.GatherSafe(tab.axis, tab.idx)
+
+		_ = handleErrors(t, "Gather", tab.err, err, "Axis: %d, Indices: %v.", tab.axis, tab.idx) &&
+			handleReturn(t, "Gather", tab.res, res, "Axis: %d, Indices: %v.", tab.axis, tab.idx)
+	}
+
+	// the result does not share its dimensions with idx
+	idx := NewIndices([]int{2, 2}, []int{2, 0, 1, 1})
+	res := tensor.Gather(0, idx)
+	res.Dims[0] = 7
+	handleReturn(t, "Gather", []int{2, 2}, idx.Dims, "Indices after changes.")
+
+	_, err := NewIndicesSafe([]int{2}, []int{1})
+	handleErrors(t, "NewIndices", LengthMismatchError{}, err, "")
+}
+
+// requires Gather, Constructors
+func tScatter(t *testing.T) {
+	idx := NewIndices([]int{3, 1}, []int{1, 0, 1})
+	src := FromNested([][]float64{{1, 2, 3}})
+
+	tensor := Zeros([]int{3, 2})
+	tensor.Scatter(1, idx, src)
+	handleReturn(t, "Scatter", FromNested([][]float64{{0, 2, 0}, {1, 0, 3}}), tensor, "")
+
+	// repeated indices accumulate
+	idx = NewIndices([]int{1, 2}, []int{2, 2})
+	src = FromNested([][]float64{{1}, {2}})
+
+	tensor = Ones([]int{3, 2})
+	tensor.ScatterAdd(0, idx, src)
+	handleReturn(t, "ScatterAdd", FromNested([][]float64{{1, 1, 2}, {1, 1, 3}}), tensor, "")
+
+	// ScatterAdd undoes Gather for gradients
+	emb := FromNested([][]float64{{1, 2}, {3, 4}, {5, 6}})
+	tokens := NewIndices([]int{2, 2}, []int{2, 2, 0, 0})
+	grad := Zeros(emb.Dims)
+	grad.ScatterAdd(1, tokens, Ones(emb.Gather(1, tokens).Dims))
+	handleReturn(t, "ScatterAdd", FromNested([][]float64{{1, 1}, {0, 0}, {1, 1}}), grad, "")
+
+	errTable := []struct {
+		axis int
+		idx  Indices
+		src  Tensor
+		err  error
+	}{
+		{2, NewIndices([]int{1, 1}, []int{0}), Zeros([]int{1, 1}), AxisError{}},
+		{0, NewIndices([]int{1, 1}, []int{0}), Zeros([]int{1, 2}), ShapeMismatchError{}},
+		{0, NewIndices([]int{1, 1}, []int{3}), Zeros([]int{1, 1}), PointOutOfBoundsError{}},
+	}
+
+	for _, tab := range errTable {
+		tensor := Zeros([]int{3, 2})
+		handleErrors(t, "Scatter", tab.err, tensor.ScatterSafe(tab.axis, tab.idx, tab.src), "")
+		handleErrors(t, "ScatterAdd", tab.err, tensor.ScatterAddSafe(tab.axis, tab.idx, tab.src), "")
+	}
+}
+
+// requires FromNested
+func tIndexSelect(t *testing.T) {
+	tensor := FromNested([][]float64{{1, 2}, {3, 4}, {5, 6}})
+
+	table := []struct {
+		axis    int
+		indices []int
+		res     Tensor
+		err     error
+	}{
+		{1, []int{2, 0, 2}, FromNested([][]float64{{5, 6}, {1, 2}, {5, 6}}), nil},
+		{0, []int{1}, FromNested([][]float64{{2}, {4}, {6}}), nil},
+		{0, []int{1, 1, 0}, FromNested([][]float64{{2, 2, 1}, {4, 4, 3}, {6, 6, 5}}), nil},
+
+		{2, []int{0}, Tensor{}, AxisError{}},
+		{1, nil, Tensor{}, DimsValueError{}},
+		{1, []int{0, 3}, Tensor{}, PointOutOfBoundsError{}},
+		{0, []int{-1}, Tensor{}, PointOutOfBoundsError{}},
+	}
+
+	for _, tab := range table {
+		res, err := tensor.IndexSelectSafe(tab.axis, tab.indices)
+
+		_ = handleErrors(t, "IndexSelect", tab.err, err, "Axis: %d, Indices: %v.", tab.axis, tab.indices) &&
+			handleReturn(t, "IndexSelect", tab.res, res, "Axis: %d, Indices: %v.", tab.axis, tab.indices)
+	}
+}
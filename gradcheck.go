@@ -0,0 +1,157 @@
+package tensors
+
+import (
+	"math"
+	"sort"
+)
+
+// GradMismatch documents a single value where the analytic gradient given to GradCheck did not
+// match the numerical gradient.
+type GradMismatch struct {
+	// Input is the position of the Tensor in the inputs given to GradCheck
+	Input int
+
+	// Index and Point give the location of the value in that Tensor
+	Index int
+	Point []int
+
+	Analytic float64
+	Numeric  float64
+}
+
+// GradCheck compares analytic gradients against numerical gradients computed with central
+// finite differences. fn computes a scalar from the inputs; analytic should contain the gradient
+// of fn with respect to each input, with the same dimensions.
+//
+// Each value of each input is perturbed by ±eps, and the numerical gradient is
+// (fn(x+eps) - fn(x-eps)) / (2*eps). A value is reported as a mismatch if:
+//		|analytic - numeric| > tol * max(1, |analytic|, |numeric|)
+// so that tol acts as an absolute tolerance for small gradients and a relative tolerance for
+// large ones. Mismatches are returned in order of input, then index. If there are no mismatches,
+// GradCheck returns nil.
+//
+// The values of the inputs are processed with MapApply, using the given ThreadingOptions. If
+// options is nil, GradCheck will run as a single thread. Each thread operates on its own copy of
+// the inputs, so fn must not depend on the identity of the Tensors given to it. The original
+// inputs are not modified.
+//
+// GradCheck will panic if any of the following conditions are met:
+//		(0) If fn is nil, this will cause ErrNilGradFunc.
+//		(1) If eps ≤ 0 or tol < 0, this will cause ErrGradOptions.
+//		(2) If len(analytic) != len(inputs), this will cause a LengthMismatchError.
+//		(3) If any of analytic does not have the same dimensions as its input, this will cause a
+//			ShapeMismatchError naming the index of the input, or ErrLayoutMismatch if only its
+//			Layout differs.
+// GradCheckSafe returns these errors instead.
+func GradCheck(fn func([]Tensor) float64, inputs, analytic []Tensor, eps, tol float64,
+	options *ThreadingOptions) []GradMismatch {
+
+	ms, err := GradCheckSafe(fn, inputs, analytic, eps, tol, options)
+	if err != nil {
+		panic(err)
+	}
+
+	return ms
+}
+
+// GradCheckSafe undergoes the same process as GradCheck, but returns error instead of panicking.
+func GradCheckSafe(fn func([]Tensor) float64, inputs, analytic []Tensor, eps, tol float64,
+	options *ThreadingOptions) ([]GradMismatch, error) {
+
+	if fn == nil {
+		return nil, ErrNilGradFunc
+	} else if !(eps > 0) || !(tol >= 0) {
+		return nil, ErrGradOptions
+	} else if len(analytic) != len(inputs) {
+		return nil, LengthMismatchError{"analytic gradients", len(analytic), len(inputs)}
+	}
+
+	for i := range inputs {
+		if err := checkEquals(inputs[i].Interpreter, analytic[i].Interpreter, i); err != nil {
+			return nil, err
+		}
+	}
+
+	threads := 1
+	if options != nil && options.NumThreads > 1 {
+		threads = options.NumThreads
+	}
+
+	// each thread takes a copy of the inputs when it needs one, and returns it once finished
+	copies := make(chan []Tensor, threads)
+	for i := 0; i < threads; i++ {
+		c := make([]Tensor, len(inputs))
+		for j, t := range inputs {
+			c[j] = Tensor{Interpreter: t.Interpreter, Values: make([]float64, len(t.Values))}
+			copy(c[j].Values, t.Values)
+		}
+
+		copies <- c
+	}
+
+	var mismatches []GradMismatch
+	results := make(chan GradMismatch, threads)
+	done := make(chan struct{})
+
+	go func() {
+		for m := range results {
+			mismatches = append(mismatches, m)
+		}
+
+		close(done)
+	}()
+
+	for n, input := range inputs {
+		n := n
+
+		err := input.MapApplySafe(func(_ []int, index int) error {
+			c := <-copies
+			defer func() { copies <- c }()
+
+			orig := c[n].Values[index]
+			c[n].Values[index] = orig + eps
+			plus := fn(c)
+			c[n].Values[index] = orig - eps
+			minus := fn(c)
+			c[n].Values[index] = orig
+
+			a, num := analytic[n].Values[index], (plus-minus)/(2*eps)
+			scale := math.Max(1, math.Max(math.Abs(a), math.Abs(num)))
+
+			// written so that NaNs are reported
+			if !(math.Abs(a-num) <= tol*scale) {
+				results <- GradMismatch{n, index, input.Point(index), a, num}
+			}
+
+			return nil
+		}, copyOptions(options))
+
+		if err != nil {
+			close(results)
+			return nil, err
+		}
+	}
+
+	close(results)
+	<-done
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].Input != mismatches[j].Input {
+			return mismatches[i].Input < mismatches[j].Input
+		}
+
+		return mismatches[i].Index < mismatches[j].Index
+	})
+
+	return mismatches, nil
+}
+
+// copyOptions returns a copy of options, so that MapApply's defaults do not modify the original.
+func copyOptions(options *ThreadingOptions) *ThreadingOptions {
+	if options == nil {
+		return nil
+	}
+
+	o := *options
+	return &o
+}
@@ -0,0 +1,74 @@
+package tensors
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// requires Autodiff, MapApply
+func tGradCheck(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	inputs := []Tensor{
+		NewRandom([]int{3, 2}, Normal(0, 1), rng),
+		NewRandom([]int{4, 3}, Normal(0, 1), rng),
+	}
+
+	fn := func(ts []Tensor) float64 {
+		return MatMul(ts[0], ts[1]).Apply(sigmoid).Sum()
+	}
+
+	tape := NewTape()
+	a, b := tape.Var(inputs[0]), tape.Var(inputs[1])
+	a.MatMul(b).Sigmoid().Sum().Backward()
+
+	for _, options := range []*ThreadingOptions{nil, {NumThreads: 4, OpsPerThread: 2}} {
+		ms, err := GradCheckSafe(fn, inputs, []Tensor{a.Grad, b.Grad}, 1e-6, 1e-6, options)
+		_ = handleErrors(t, "GradCheck", nil, err, "") &&
+			handleReturn(t, "GradCheck", []GradMismatch(nil), ms, "Options: %v.", options)
+	}
+
+	// corrupt two values of the gradient, which should be reported in order
+	wrong := []Tensor{
+		{Interpreter: a.Grad.Interpreter, Values: append([]float64{}, a.Grad.Values...)},
+		b.Grad,
+	}
+	wrong[0].Values[4] += 1
+	wrong[0].Values[1] -= 1
+
+	for _, options := range []*ThreadingOptions{nil, {NumThreads: 3}} {
+		ms, err := GradCheckSafe(fn, inputs, wrong, 1e-6, 1e-6, options)
+		if !handleErrors(t, "GradCheck", nil, err, "") {
+			continue
+		} else if len(ms) != 2 {
+			t.Errorf("GradCheck: Expected 2 mismatches, Got %v.", ms)
+			continue
+		}
+
+		handleReturn(t, "GradCheck", []int{1, 0}, ms[0].Point, "")
+		handleReturn(t, "GradCheck", []int{0, 4, 1, 1}, []int{ms[0].Input, ms[1].Index, ms[1].Point[0], ms[1].Point[1]}, "")
+		if diff := ms[1].Analytic - ms[1].Numeric; diff < 0.99 || diff > 1.01 {
+			t.Errorf("GradCheck: Bad mismatch values. Got %+v.", ms[1])
+		}
+	}
+
+	// the inputs must not have been modified
+	handleReturn(t, "GradCheck", a.Value, inputs[0], "")
+
+	errTable := []struct {
+		fn       func([]Tensor) float64
+		analytic []Tensor
+		eps, tol float64
+		err      error
+	}{
+		{nil, wrong, 1e-6, 1e-6, ErrNilGradFunc},
+		{fn, wrong, 0, 1e-6, ErrGradOptions},
+		{fn, wrong, 1e-6, -1, ErrGradOptions},
+		{fn, wrong[:1], 1e-6, 1e-6, LengthMismatchError{}},
+		{fn, []Tensor{b.Grad, a.Grad}, 1e-6, 1e-6, ShapeMismatchError{}},
+	}
+
+	for _, tab := range errTable {
+		_, err := GradCheckSafe(tab.fn, inputs, tab.analytic, tab.eps, tab.tol, nil)
+		handleErrors(t, "GradCheck", tab.err, err, "")
+	}
+}
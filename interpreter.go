@@ -6,7 +6,8 @@ type Interpreter struct {
 	// Dims makes up the sizes of each dimension. Eg: [width, height, depth, etc..]
 	// Values are interpreted such that incrementing indices in Values increases (in order) the
 	// indices of Dims[0], Dims[1], ... Dims[N]. In other words, changes in the indices of
-	// higher-order dimensions result in greater changes in the base index.
+	// higher-order dimensions result in greater changes in the base index. This is reversed if
+	// Layout is RowMajor.
 	//
 	// Dims should not be altered - it is set at construction. It is made public to be visible to
 	// marshallers. However, it can (and should) be accessed if need be.
@@ -16,8 +17,15 @@ type Interpreter struct {
 	// Sizes[0] = Dims[0]; Sizes[1] = Dims[0]*Dims[1]; Sizes[N] = len(Values).
 	//
 	// Sizes should not be altered - it is set at construction. It is made public to be visible to
-	// marshallers.
+	// marshallers. Sizes does not depend on Layout. A rank-0 Interpreter (see
+	// NewInterpreterExtended) has Sizes [1], so that it is still distinct from the zero value
+	// after marshallers that drop empty slices, such as encoding/gob.
 	Sizes []int
+
+	// Layout gives the order in which values are stored. Every constructor gives ColumnMajor, as
+	// described above; WithLayout may be used to change it. More information can be found in the
+	// documentation for Layout.
+	Layout Layout
 }
 
 // NewInterpreter is fairly self-explanatory; it returns a new Interpreter, based on the provided
@@ -41,7 +49,8 @@ func NewInterpreter(dims []int) Interpreter {
 
 // SafeInterpreter returns a new Interpreter or any error. SafeInterpreter will return error if
 // any one of the provided dimensions are <= 1, or if len(dims) == 0. These errors will either be
-// ErrZeroDims or a DimsValueError.
+// ErrZeroDims or a DimsValueError. If the total number of values would overflow int,
+// SafeInterpreter returns an OverflowError.
 //
 // Note: SafeInterpreter does NOT make a copy of dims -- if the array that dims references is
 // modified, dims will be also.
@@ -56,14 +65,144 @@ func NewInterpreterSafe(dims []int) (Interpreter, error) {
 		}
 	}
 
+	sizes, err := makeSizes(dims)
+	if err != nil {
+		return Interpreter{}, err
+	}
+
+	return Interpreter{dims, sizes, ColumnMajor}, nil
+}
+
+// maxInt is the largest value of int
+const maxInt = int(^uint(0) >> 1)
+
+// makeSizes returns the Sizes corresponding to dims, which must all be non-negative, or an
+// OverflowError if they cannot be represented as an int.
+func makeSizes(dims []int) ([]int, error) {
 	sizes := make([]int, len(dims))
-	sizes[0] = dims[0]
 
-	for i := 1; i < len(sizes); i++ {
-		sizes[i] = sizes[i-1] * dims[i]
+	size := 1
+	for i, d := range dims {
+		if d != 0 && size > maxInt/d {
+			return nil, OverflowError{dims, i}
+		}
+
+		size *= d
+		sizes[i] = size
 	}
 
-	return Interpreter{dims, sizes}, nil
+	return sizes, nil
+}
+
+// NewInterpreterExtended returns a new Interpreter, as with NewInterpreter, but additionally
+// allows Interpreters that NewInterpreter does not: those with no dimensions (rank 0), which have
+// a single value, and those with dimensions of size zero, which have no values. These are needed
+// for reductions to a scalar, and for empty batches -- eg. the final minibatch of a dataset.
+//
+// For a rank-0 Interpreter, the only point is the empty point, []int{}, which has index 0.
+// Increment and Decrement return false, as there is no other point to move to, and MapApply calls
+// its function once. For an Interpreter with a zero-sized dimension, there are no points, so
+// CheckPoint and CheckIndex always return error, and MapApply never calls its function.
+//
+// Add, Sub, Mul and Div, the comparisons (eg. Equal), Where, SumAxis, SetRegion and the operations
+// on Variables built from them accept Tensors with either kind of Interpreter; broadcasting a size
+// of 1 against a size of 0 gives 0. The Fourier transforms return a DimsValueError for Tensors with
+// no values. Other operations are only defined for the Interpreters given by NewInterpreter, unless
+// their documentation says otherwise. Validate, which checks against NewInterpreterSafe, does not
+// accept either kind of Interpreter.
+//
+// NewInterpreterExtended will panic if any of the error conditions from
+// NewInterpreterExtendedSafe are met.
+func NewInterpreterExtended(dims []int) Interpreter {
+	in, err := NewInterpreterExtendedSafe(dims)
+	if err != nil {
+		panic(err)
+	}
+
+	return in
+}
+
+// NewInterpreterExtendedSafe undergoes the same process as NewInterpreterExtended, but returns
+// error instead of panicking. NewInterpreterExtendedSafe returns a DimsValueError if any of the
+// dimensions are negative, or an OverflowError if the number of values would overflow int. As
+// with NewInterpreterSafe, dims is not copied; if it is nil, Dims is set to an empty slice.
+func NewInterpreterExtendedSafe(dims []int) (Interpreter, error) {
+	if dims == nil {
+		dims = []int{}
+	}
+
+	for i, d := range dims {
+		if d < 0 {
+			return Interpreter{}, DimsValueError{dims, i}
+		}
+	}
+
+	// rank 0 is recorded as a Size of 1, so that it survives marshalling unlike empty slices
+	if len(dims) == 0 {
+		return Interpreter{dims, []int{1}, ColumnMajor}, nil
+	}
+
+	sizes, err := makeSizes(dims)
+	if err != nil {
+		return Interpreter{}, err
+	}
+
+	return Interpreter{dims, sizes, ColumnMajor}, nil
+}
+
+// NewInterpreterLimit returns a new Interpreter, as with NewInterpreter, but additionally
+// requires that it has no more than limit values. This is intended for dimensions from untrusted
+// input, such as when decoding files, so that a malicious or corrupt header cannot cause a huge
+// allocation.
+//
+// NewInterpreterLimit will panic if any of the error conditions from NewInterpreterLimitSafe are
+// met.
+func NewInterpreterLimit(dims []int, limit int) Interpreter {
+	in, err := NewInterpreterLimitSafe(dims, limit)
+	if err != nil {
+		panic(err)
+	}
+
+	return in
+}
+
+// NewInterpreterLimitSafe undergoes the same process as NewInterpreterLimit, but returns error
+// instead of panicking. In addition to the errors from NewInterpreterSafe, NewInterpreterLimitSafe
+// returns a LimitError if the Interpreter would have more than limit values.
+func NewInterpreterLimitSafe(dims []int, limit int) (Interpreter, error) {
+	in, err := NewInterpreterSafe(dims)
+	if err != nil {
+		return Interpreter{}, err
+	} else if in.Size() > limit {
+		return Interpreter{}, LimitError{dims, limit}
+	}
+
+	return in, nil
+}
+
+// Validate checks that the Interpreter is consistent: that it would be returned by
+// NewInterpreterSafe given its Dims. This is useful for Interpreters that were built by hand or by
+// an unmarshaller, which may not have been checked. Validate returns the same errors as
+// NewInterpreterSafe, a LengthMismatchError if Sizes does not have the same length as Dims,
+// ErrBadSizes if any of Sizes are incorrect, or ErrBadLayout if Layout is not one of the defined
+// values.
+func (in Interpreter) Validate() error {
+	valid, err := NewInterpreterSafe(in.Dims)
+	if err != nil {
+		return err
+	} else if in.Layout != ColumnMajor && in.Layout != RowMajor {
+		return ErrBadLayout
+	} else if len(in.Sizes) != len(valid.Sizes) {
+		return LengthMismatchError{"sizes", len(in.Sizes), len(valid.Sizes)}
+	}
+
+	for i := range in.Sizes {
+		if in.Sizes[i] != valid.Sizes[i] {
+			return ErrBadSizes
+		}
+	}
+
+	return nil
 }
 
 // CheckPoint is mostly for internal use. It checks that the point is within the space defined by
@@ -80,11 +219,14 @@ func NewInterpreterSafe(dims []int) (Interpreter, error) {
 //			according to the Interpreter. In other words, if point[i] ≥ in.Dims[i] for any i.
 // (0) will return an ErrZeroPoint, (1) will return a LengthMismatchError, (2) and (3) will return
 // PointOutOfBoundsError.
+//
+// For a rank-0 Interpreter from NewInterpreterExtended, the empty point is valid. It is not valid
+// for the zero value of Interpreter, which has no points.
 func (in Interpreter) CheckPoint(point []int) error {
-	if len(point) == 0 {
+	if len(point) == 0 && (len(in.Dims) != 0 || len(in.Sizes) == 0) {
 		return ErrZeroPoint
-	} else if len(point) != len(in.Sizes) {
-		return LengthMismatchError{"point", len(point), len(in.Sizes)}
+	} else if len(point) != len(in.Dims) {
+		return LengthMismatchError{"point", len(point), len(in.Dims)}
 	}
 
 	for i, v := range point {
@@ -97,8 +239,8 @@ func (in Interpreter) CheckPoint(point []int) error {
 }
 
 // Equals returns whether or not two Interpreters are equal, i.e. whether or not their dimensions
-// are the same. When called with Tensors, it will not examine the underlying values, but will
-// still return true if the dimensions are equivalent.
+// and layouts are the same. When called with Tensors, it will not examine the underlying values,
+// but will still return true if the dimensions are equivalent.
 func Equals(a, b Interpreter) bool {
 	eq := func(x, y []int) bool {
 		if len(x) != len(y) {
@@ -114,7 +256,7 @@ func Equals(a, b Interpreter) bool {
 		return true
 	}
 
-	return eq(a.Dims, b.Dims) && eq(a.Sizes, b.Sizes)
+	return eq(a.Dims, b.Dims) && eq(a.Sizes, b.Sizes) && a.Layout == b.Layout
 }
 
 // Index returns the index in the base array that the given point corresponds to. Index will panic
@@ -145,6 +287,17 @@ func (in Interpreter) IndexSafe(point []int) (int, error) {
 // Please note: IndexFast is only marginally faster than Index; it is simply avoiding a call to
 // Interpreter.CheckPoint().
 func (in Interpreter) IndexFast(point []int) int {
+	if len(point) == 0 {
+		return 0
+	} else if in.Layout == RowMajor {
+		index := point[0]
+		for i := 1; i < len(in.Dims); i++ {
+			index = index*in.Dims[i] + point[i]
+		}
+
+		return index
+	}
+
 	index := point[0]
 	for i := 1; i < len(in.Sizes); i++ {
 		index += point[i] * in.Sizes[i-1]
@@ -191,6 +344,18 @@ func (in Interpreter) PointSafe(index int) ([]int, error) {
 	}
 
 	p := make([]int, len(in.Dims))
+	if len(p) == 0 {
+		return p, nil
+	} else if in.Layout == RowMajor {
+		for i := len(p) - 1; i >= 1; i-- {
+			p[i] = index % in.Dims[i]
+			index /= in.Dims[i]
+		}
+
+		p[0] = index
+		return p, nil
+	}
+
 	for i := len(p) - 1; i >= 1; i-- {
 		p[i] = index / in.Sizes[i-1]
 		index %= in.Sizes[i-1]
@@ -200,12 +365,32 @@ func (in Interpreter) PointSafe(index int) ([]int, error) {
 	return p, nil
 }
 
-// Size returns the required (and expected) length of the base array for the Interpreter.
+// Size returns the required (and expected) length of the base array for the Interpreter. The zero
+// value of Interpreter has a Size of zero.
 func (in Interpreter) Size() int {
+	// the zero value has no Sizes; a rank-0 Interpreter has Sizes [1]
+	if len(in.Sizes) == 0 {
+		return 0
+	}
+
 	// the size is equal to the size of the largest dimension.
 	return in.Sizes[len(in.Sizes)-1]
 }
 
+// Clone returns a deep copy of the Interpreter, so that neither Dims nor Sizes are shared with the
+// original. This is useful because NewInterpreter does not copy the dims it is given.
+func (in Interpreter) Clone() Interpreter {
+	// the zero value is returned as it is, so that the copy is equal to it
+	if len(in.Sizes) == 0 {
+		return in
+	}
+
+	c := Interpreter{make([]int, len(in.Dims)), make([]int, len(in.Sizes)), in.Layout}
+	copy(c.Dims, in.Dims)
+	copy(c.Sizes, in.Sizes)
+	return c
+}
+
 // Increment increases the index corresponding to the point by 1. If the point is already at the
 // maximum index (so incrementing would overflow), Increment returns false. Otherwise, it returns
 // true. Additionally, Increment WILL NOT preserve the original value of the point if it is at its
@@ -241,20 +426,18 @@ func (in Interpreter) IncrementSafe(point []int) (bool, error) {
 //
 // IncrementFast makes debugging your code harder.
 func (in Interpreter) IncrementFast(point []int) bool {
-	for i := 0; i < len(in.Dims); i++ {
+	for n := 0; n < len(in.Dims); n++ {
+		i := in.axisOrder(n)
+
 		point[i]++
 		if point[i] < in.Dims[i] {
-			break
+			return true
 		}
 
 		point[i] = 0
-
-		if i == len(in.Dims)-1 {
-			return false
-		}
 	}
 
-	return true
+	return false
 }
 
 // Decrement is the decreasing analog to Increment. If point is at its mimimum value, Decrement
@@ -284,20 +467,28 @@ func (in Interpreter) DecrementSafe(point []int) (bool, error) {
 
 // DecrementFast is the decreasing analog to IncrementFast.
 func (in Interpreter) DecrementFast(point []int) bool {
-	for i := 0; i < len(in.Dims); i++ {
+	for n := 0; n < len(in.Dims); n++ {
+		i := in.axisOrder(n)
+
 		point[i]--
 		if point[i] >= 0 {
-			break
+			return true
 		}
 
 		point[i] = in.Dims[i] - 1
+	}
 
-		if i == len(in.Dims)-1 {
-			return false
-		}
+	return false
+}
+
+// axisOrder returns the axis that is the nth-fastest to vary in the base array: n itself for
+// ColumnMajor, and counting from the last axis for RowMajor.
+func (in Interpreter) axisOrder(n int) int {
+	if in.Layout == RowMajor {
+		return len(in.Dims) - 1 - n
 	}
 
-	return true
+	return n
 }
 
 // IncreaseBy increases the index corresponding to the point by the given value. IncreaseBy will
@@ -357,3 +548,42 @@ func (in Interpreter) IncreaseBySafe(point []int, change int) (bool, error) {
 
 	return true, nil
 }
+
+// stride returns the difference in index between two points that differ by one along the given
+// axis.
+func (in Interpreter) stride(axis int) int {
+	if in.Layout == RowMajor {
+		s := 1
+		for _, d := range in.Dims[axis+1:] {
+			s *= d
+		}
+
+		return s
+	} else if axis == 0 {
+		return 1
+	}
+
+	return in.Sizes[axis-1]
+}
+
+// split divides the base array around the given axis, returning the number of 'outer' blocks,
+// and the size of each 'inner' block -- which is also the stride of the axis. The index of a
+// point is then given by: outer*Dims[axis]*inner + point[axis]*inner + (remainder), where each
+// outer block is contiguous.
+func (in Interpreter) split(axis int) (outer, inner int) {
+	inner = in.stride(axis)
+	if in.Size() == 0 {
+		return 0, inner
+	}
+
+	return in.Size() / (inner * in.Dims[axis]), inner
+}
+
+// checkAxis returns an AxisError if axis is not a valid dimension of the Interpreter.
+func (in Interpreter) checkAxis(axis int) error {
+	if axis < 0 || axis >= len(in.Dims) {
+		return AxisError{axis, len(in.Dims)}
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package tensors
 
 import (
+	"bytes"
+	"encoding/gob"
 	"testing"
 )
 
@@ -10,17 +12,21 @@ func tNewInterpreter(t *testing.T) {
 		in   Interpreter
 		err  error
 	}{
-		{[]int{1, 2, 3}, Interpreter{[]int{1, 2, 3}, []int{1, 2, 6}}, nil},
-		{[]int{1, 2, 3, 4}, Interpreter{[]int{1, 2, 3, 4}, []int{1, 2, 6, 24}}, nil},
-		{[]int{1, 2, 3, 4, 5}, Interpreter{[]int{1, 2, 3, 4, 5}, []int{1, 2, 6, 24, 120}}, nil},
-		{[]int{2, 1, 3}, Interpreter{[]int{2, 1, 3}, []int{2, 2, 6}}, nil},
-		{[]int{2, 3, 1}, Interpreter{[]int{2, 3, 1}, []int{2, 6, 6}}, nil},
+		{[]int{1, 2, 3}, Interpreter{[]int{1, 2, 3}, []int{1, 2, 6}, ColumnMajor}, nil},
+		{[]int{1, 2, 3, 4}, Interpreter{[]int{1, 2, 3, 4}, []int{1, 2, 6, 24}, ColumnMajor}, nil},
+		{[]int{1, 2, 3, 4, 5},
+			Interpreter{[]int{1, 2, 3, 4, 5}, []int{1, 2, 6, 24, 120}, ColumnMajor}, nil},
+		{[]int{2, 1, 3}, Interpreter{[]int{2, 1, 3}, []int{2, 2, 6}, ColumnMajor}, nil},
+		{[]int{2, 3, 1}, Interpreter{[]int{2, 3, 1}, []int{2, 6, 6}, ColumnMajor}, nil},
 
 		{nil, Interpreter{}, ErrZeroDims},
 		{[]int{}, Interpreter{}, ErrZeroDims},
 		{[]int{0, 1, 2}, Interpreter{}, DimsValueError{}},
 		{[]int{1, 0, 2}, Interpreter{}, DimsValueError{}},
 		{[]int{1, 2, 0}, Interpreter{}, DimsValueError{}},
+
+		{[]int{maxInt, 2}, Interpreter{}, OverflowError{}},
+		{[]int{1 << 20, 1 << 20, 1 << 20, 1 << 20}, Interpreter{}, OverflowError{}},
 	}
 
 	for _, tab := range table {
@@ -32,6 +38,158 @@ func tNewInterpreter(t *testing.T) {
 	}
 }
 
+// requires NewInterpreter
+func tNewInterpreterLimit(t *testing.T) {
+	table := []struct {
+		dims  []int
+		limit int
+		err   error
+	}{
+		{[]int{2, 3}, 6, nil},
+		{[]int{2, 3}, 100, nil},
+
+		{[]int{2, 3}, 5, LimitError{}},
+		{[]int{maxInt, 2}, maxInt, OverflowError{}},
+		{[]int{0}, 100, DimsValueError{}},
+	}
+
+	for _, tab := range table {
+		in, err := NewInterpreterLimitSafe(tab.dims, tab.limit)
+
+		_ = handleErrors(t, "NewInterpreterLimit", tab.err, err, "Dims: %v, Limit: %d.", tab.dims, tab.limit) &&
+			handleReturn(t, "NewInterpreterLimit", NewInterpreter(tab.dims), in, "Dims: %v.", tab.dims)
+	}
+}
+
+// requires NewInterpreter, Index, Point, Increment, MapApply
+func tNewInterpreterExtended(t *testing.T) {
+	table := []struct {
+		dims []int
+		in   Interpreter
+		err  error
+	}{
+		{nil, Interpreter{[]int{}, []int{1}, ColumnMajor}, nil},
+		{[]int{2, 3}, NewInterpreter([]int{2, 3}), nil},
+		{[]int{2, 0, 3}, Interpreter{[]int{2, 0, 3}, []int{2, 0, 0}, ColumnMajor}, nil},
+		{[]int{0}, Interpreter{[]int{0}, []int{0}, ColumnMajor}, nil},
+
+		{[]int{2, -1}, Interpreter{}, DimsValueError{}},
+		{[]int{maxInt, 2}, Interpreter{}, OverflowError{}},
+	}
+
+	for _, tab := range table {
+		in, err := NewInterpreterExtendedSafe(tab.dims)
+
+		_ = handleErrors(t, "NewInterpreterExtended", tab.err, err, "Dims: %v.", tab.dims) &&
+			handleReturn(t, "NewInterpreterExtended", tab.in, in, "Dims: %v.", tab.dims)
+	}
+
+	// rank 0: a single value at the empty point
+	scalar := NewInterpreterExtended(nil)
+	if scalar.Size() != 1 {
+		t.Errorf("NewInterpreterExtended: Bad rank-0 Size. Expected 1, Got %d.", scalar.Size())
+	}
+
+	index, err := scalar.IndexSafe([]int{})
+	_ = handleErrors(t, "NewInterpreterExtended", nil, err, "Rank-0 Index.") &&
+		handleReturn(t, "NewInterpreterExtended", 0, index, "Rank-0 Index.")
+
+	point, err := scalar.PointSafe(0)
+	_ = handleErrors(t, "NewInterpreterExtended", nil, err, "Rank-0 Point.") &&
+		handleReturn(t, "NewInterpreterExtended", []int{}, point, "Rank-0 Point.")
+
+	_, err = scalar.PointSafe(1)
+	handleErrors(t, "NewInterpreterExtended", ErrIndexSize, err, "Rank-0 Point.")
+
+	cont, err := scalar.IncrementSafe([]int{})
+	_ = handleErrors(t, "NewInterpreterExtended", nil, err, "Rank-0 Increment.") &&
+		handleReturn(t, "NewInterpreterExtended", false, cont, "Rank-0 Increment.")
+
+	cont, err = scalar.DecrementSafe([]int{})
+	_ = handleErrors(t, "NewInterpreterExtended", nil, err, "Rank-0 Decrement.") &&
+		handleReturn(t, "NewInterpreterExtended", false, cont, "Rank-0 Decrement.")
+
+	var calls int
+	scalar.MapApply(func(p []int, i int) { calls++ }, nil)
+	handleReturn(t, "NewInterpreterExtended", 1, calls, "Rank-0 MapApply calls.")
+
+	// the zero value is not rank 0: it has no values, and no valid points
+	var zero Interpreter
+	handleReturn(t, "NewInterpreterExtended", 0, zero.Size(), "Zero value Size.")
+	handleErrors(t, "NewInterpreterExtended", ErrZeroPoint, zero.CheckPoint([]int{}), "Zero value.")
+	handleReturn(t, "NewInterpreterExtended", zero, zero.Clone(), "Zero value Clone.")
+
+	// zero-sized dimensions: no values, and so no valid points
+	empty := NewInterpreterExtended([]int{2, 0})
+	if empty.Size() != 0 {
+		t.Errorf("NewInterpreterExtended: Bad empty Size. Expected 0, Got %d.", empty.Size())
+	}
+
+	_, err = empty.IndexSafe([]int{0, 0})
+	handleErrors(t, "NewInterpreterExtended", PointOutOfBoundsError{}, err, "Empty Index.")
+
+	_, err = empty.PointSafe(0)
+	handleErrors(t, "NewInterpreterExtended", ErrIndexSize, err, "Empty Point.")
+
+	calls = 0
+	empty.MapApply(func(p []int, i int) { calls++ }, &ThreadingOptions{NumThreads: 4, OpsPerThread: 2})
+	handleReturn(t, "NewInterpreterExtended", 0, calls, "Empty MapApply calls.")
+
+	// a Tensor with a zero-sized dimension holds no values, and a scalar exactly one
+	handleReturn(t, "NewInterpreterExtended", 0, len(NewTensorExtended([]int{0, 3}).Values), "Empty Tensor.")
+	handleReturn(t, "NewInterpreterExtended", []float64{2.5}, Scalar(2.5).Values, "Scalar.")
+
+	// encoding/gob drops empty slices, but a rank-0 Tensor must still not become the zero value
+	var buf bytes.Buffer
+	var dec Tensor
+	err = gob.NewEncoder(&buf).Encode(Scalar(2.5))
+	if handleErrors(t, "NewInterpreterExtended", nil, err, "Gob encode.") {
+		err = gob.NewDecoder(&buf).Decode(&dec)
+		_ = handleErrors(t, "NewInterpreterExtended", nil, err, "Gob decode.") &&
+			handleReturn(t, "NewInterpreterExtended", 1, dec.Size(), "Gob Size.") &&
+			handleReturn(t, "NewInterpreterExtended", true, Equals(dec.Interpreter, scalar), "Gob.") &&
+			handleReturn(t, "NewInterpreterExtended", 2.5, dec.PointValue([]int{}), "Gob value.")
+	}
+}
+
+// requires NewInterpreter
+func tValidate(t *testing.T) {
+	table := []struct {
+		in  Interpreter
+		err error
+	}{
+		{NewInterpreter([]int{2, 3, 4}), nil},
+		{Interpreter{[]int{2, 3}, []int{2, 6}, ColumnMajor}, nil},
+		{Interpreter{[]int{2, 3}, []int{2, 6}, RowMajor}, nil},
+
+		{Interpreter{}, ErrZeroDims},
+		{Interpreter{[]int{2, -3}, []int{2, -6}, ColumnMajor}, DimsValueError{}},
+		{Interpreter{[]int{maxInt, 3}, []int{maxInt, 3}, ColumnMajor}, OverflowError{}},
+		{Interpreter{[]int{2, 3}, []int{2}, ColumnMajor}, LengthMismatchError{}},
+		{Interpreter{[]int{2, 3}, []int{2, 5}, ColumnMajor}, ErrBadSizes},
+		{Interpreter{[]int{2, 3}, []int{2, 6}, Layout(2)}, ErrBadLayout},
+	}
+
+	for _, tab := range table {
+		err := tab.in.Validate()
+		handleErrors(t, "Validate", tab.err, err, "Interpreter: %v.", tab.in)
+	}
+
+	tensors := []struct {
+		t   Tensor
+		err error
+	}{
+		{NewTensor([]int{2, 3}), nil},
+		{Tensor{Interpreter: NewInterpreter([]int{2, 3}), Values: make([]float64, 5)}, LengthMismatchError{}},
+		{Tensor{Values: []float64{1}}, ErrZeroDims},
+	}
+
+	for _, tab := range tensors {
+		err := tab.t.Validate()
+		handleErrors(t, "Tensor.Validate", tab.err, err, "Tensor: %v, Values: %v.", tab.t.Interpreter, tab.t.Values)
+	}
+}
+
 // requires NewInterpreter
 func tEquals(t *testing.T) {
 	table := []struct{
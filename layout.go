@@ -0,0 +1,101 @@
+package tensors
+
+import "strconv"
+
+// Layout gives the order in which the values of an Interpreter are stored in its base array.
+//
+// With ColumnMajor -- the default, given by every constructor -- Dims[0] varies fastest, so values
+// along Dims[0] are contiguous. With RowMajor (also called C-order), the last dimension varies
+// fastest instead, which is the order assumed by most other libraries and file formats.
+//
+// Index, Point, Increment, Decrement, IncreaseBy, MapApply and Format all honor the Layout of an
+// Interpreter, as do their variants, and so do PointValue, SetPoint, CopyFrom and SetRegion.
+// Operations that act on each value independently, such as Apply, Scale and Sum, are unaffected by
+// it. Add, Sub, Mul, Div, the comparisons (eg. Equal), Where and SumAxis also honor the Layout of
+// their operands, always giving results that are ColumnMajor, and MaskedFill gives a result with
+// the same Layout as the Tensor.
+//
+// Every other operation -- and every function in the linalg package -- returns
+// ErrNotColumnMajor (wrapped in an OpError, as described there) if given an operand that is not
+// ColumnMajor. Such Tensors should be converted with ToLayout first. Functions that accept any
+// Layout but require their arguments to match -- the Optimizers, Variable.BackwardWith and
+// GradCheck -- return ErrLayoutMismatch for arguments that differ only in their Layout.
+//
+// Note that adding Layout to Interpreter means that unkeyed composite literals, such as
+// Interpreter{dims, sizes}, no longer compile. Interpreters should be built by the constructors,
+// or with keyed fields.
+type Layout uint8
+
+const (
+	// ColumnMajor is the default Layout, where Dims[0] varies fastest.
+	ColumnMajor Layout = iota
+
+	// RowMajor is the Layout where the last dimension varies fastest.
+	RowMajor
+)
+
+// String returns the name of the Layout, or "Layout(n)" if it is not one of the defined values.
+func (l Layout) String() string {
+	switch l {
+	case ColumnMajor:
+		return "ColumnMajor"
+	case RowMajor:
+		return "RowMajor"
+	default:
+		return "Layout(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+// WithLayout returns a copy of the Interpreter with the given Layout. Dims and Sizes are shared
+// with the original, and do not change. WithLayout does not move any values; it is intended for
+// interpreting values that were produced elsewhere, such as a C-order buffer:
+//	in := tensors.NewInterpreter(dims).WithLayout(tensors.RowMajor)
+//	t := tensors.Tensor{Interpreter: in, Values: buf}
+// To move the values of a Tensor into a different Layout, use ToLayout.
+func (in Interpreter) WithLayout(l Layout) Interpreter {
+	in.Layout = l
+	return in
+}
+
+// ToLayout returns a new Tensor with the same dimensions and values as t, with its Values
+// rearranged into the given Layout, so that every point has the same value as it does in t. The
+// Values of the result are always a copy, even if t already has the given Layout.
+func (t Tensor) ToLayout(l Layout) Tensor {
+	res := Tensor{Interpreter: t.WithLayout(l), Values: make([]float64, len(t.Values))}
+	if l == t.Layout {
+		copy(res.Values, t.Values)
+		return res
+	}
+
+	point := make([]int, len(t.Dims))
+	for _, v := range t.Values {
+		res.Values[res.IndexFast(point)] = v
+		t.IncrementFast(point)
+	}
+
+	return res
+}
+
+// checkLayout returns ErrNotColumnMajor if any of the Interpreters do not have the ColumnMajor
+// Layout, which is required by most operations.
+func checkLayout(ins ...Interpreter) error {
+	for _, in := range ins {
+		if in.Layout != ColumnMajor {
+			return ErrNotColumnMajor
+		}
+	}
+
+	return nil
+}
+
+// checkEquals returns nil if the Interpreters are Equal, ErrLayoutMismatch if they differ only in
+// their Layout, and otherwise a ShapeMismatchError naming b as the given operand.
+func checkEquals(a, b Interpreter, operand int) error {
+	if Equals(a, b) {
+		return nil
+	} else if Equals(a, b.WithLayout(a.Layout)) {
+		return ErrLayoutMismatch
+	}
+
+	return ShapeMismatchError{operand, -1, b.Dims, a.Dims}
+}
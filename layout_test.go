@@ -0,0 +1,207 @@
+package tensors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// requires IncreaseBy, MapApply, FromNested
+func tLayout(t *testing.T) {
+	// Dims [2, 3, 2], where the last axis varies fastest
+	in := NewInterpreter([]int{2, 3, 2}).WithLayout(RowMajor)
+
+	table := []struct {
+		point []int
+		index int
+	}{
+		{[]int{0, 0, 0}, 0},
+		{[]int{0, 0, 1}, 1},
+		{[]int{0, 1, 0}, 2},
+		{[]int{0, 2, 1}, 5},
+		{[]int{1, 0, 0}, 6},
+		{[]int{1, 2, 1}, 11},
+	}
+
+	for _, tab := range table {
+		index, err := in.IndexSafe(tab.point)
+		_ = handleErrors(t, "Layout", nil, err, "Index: %v.", tab.point) &&
+			handleReturn(t, "Layout", tab.index, index, "Index: %v.", tab.point)
+
+		point, err := in.PointSafe(tab.index)
+		_ = handleErrors(t, "Layout", nil, err, "Point: %d.", tab.index) &&
+			handleReturn(t, "Layout", tab.point, point, "Point: %d.", tab.index)
+	}
+
+	// Increment, Decrement and IncreaseBy must move through indices in order
+	point := []int{0, 0, 0}
+	for i := 1; i < in.Size(); i++ {
+		if !in.Increment(point) || in.Index(point) != i {
+			t.Fatalf("Layout: Bad Increment. Expected index %d, Got point %v.", i, point)
+		}
+	}
+
+	if in.Increment(point) {
+		t.Errorf("Layout: Increment did not overflow at the last point.")
+	}
+
+	point = []int{1, 2, 1}
+	if !in.Decrement(point) || !handleReturn(t, "Layout", []int{1, 2, 0}, point, "Decrement.") {
+		return
+	}
+
+	if !in.IncreaseBy(point, -7) {
+		t.Errorf("Layout: IncreaseBy overflowed.")
+	} else {
+		handleReturn(t, "Layout", []int{0, 1, 1}, point, "IncreaseBy.")
+	}
+
+	// MapApply must give matching points and indices
+	in.MapApply(func(p []int, i int) {
+		if in.Index(p) != i {
+			t.Errorf("Layout: MapApply gave point %v with index %d.", p, i)
+		}
+	}, &ThreadingOptions{NumThreads: 3, OpsPerThread: 2})
+
+	// ToLayout keeps the value at every point, and converts back exactly
+	col := FromNested([][][]float64{{{0, 1}, {2, 3}}, {{4, 5}, {6, 7}}, {{8, 9}, {10, 11}}})
+	row := col.ToLayout(RowMajor)
+	col.MapApply(func(p []int, i int) {
+		if row.PointValue(p) != col.Values[i] {
+			t.Errorf("Layout: ToLayout changed the value at %v.", p)
+		}
+	}, nil)
+
+	handleReturn(t, "Layout", []float64{0, 4, 8, 2, 6, 10, 1, 5, 9, 3, 7, 11}, row.Values, "ToLayout.")
+	handleReturn(t, "Layout", col, row.ToLayout(ColumnMajor), "ToLayout round trip.")
+
+	if Equals(col.Interpreter, row.Interpreter) {
+		t.Errorf("Layout: Equals did not compare layouts.")
+	}
+
+	// Format prints the same arrangement of values, regardless of layout
+	handleReturn(t, "Layout", fmt.Sprint(col), fmt.Sprint(row), "Format.")
+	handleReturn(t, "Layout", "Tensor(dims=[2 2 3], layout=RowMajor)\n"+fmt.Sprint(col),
+		fmt.Sprintf("%+v", row), "Format header.")
+}
+
+// requires Layout, Arithmetic, Compare, Where, Masked, SetRegion, MatMul, CopyFrom, Scatter,
+// SparseOps, Optimizer, Backward
+func tLayoutOps(t *testing.T) {
+	col := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}})
+	row := col.ToLayout(RowMajor)
+	vec := FromNested([]float64{1, 0, 2})
+
+	// operations that honor the Layout of their operands, giving ColumnMajor results
+	honored := []struct {
+		name string
+		fn   func(a Tensor) (Tensor, error)
+	}{
+		{"Add", func(a Tensor) (Tensor, error) { return AddSafe(a, vec) }},
+		{"Mul", func(a Tensor) (Tensor, error) { return MulSafe(vec, a) }},
+		{"Less", func(a Tensor) (Tensor, error) { return a.LessSafe(vec) }},
+		{"Where", func(a Tensor) (Tensor, error) { return WhereSafe(vec, a, a.Scale(-1)) }},
+		{"SumAxis", func(a Tensor) (Tensor, error) { return a.SumAxisSafe(1) }},
+		{"MaskedFill", func(a Tensor) (Tensor, error) { return a.MaskedFillSafe(vec, -1) }},
+	}
+
+	for _, tab := range honored {
+		expected, err := tab.fn(col)
+		if !handleErrors(t, tab.name, nil, err, "ColumnMajor.") {
+			continue
+		}
+
+		res, err := tab.fn(row)
+		_ = handleErrors(t, tab.name, nil, err, "RowMajor.") &&
+			handleReturn(t, tab.name, expected, res.ToLayout(ColumnMajor), "RowMajor.")
+	}
+
+	// SetRegion copies values by point when either Tensor is not ColumnMajor
+	src := FromNested([][]float64{{7, 8}})
+	for _, tab := range []struct{ dst, src Tensor }{{row, src}, {col, src.ToLayout(RowMajor)}} {
+		dst, l := tab.dst.Clone(), []Layout{tab.dst.Layout, tab.src.Layout}
+		err := dst.SetRegionSafe([]int{1, 1}, tab.src)
+		_ = handleErrors(t, "SetRegion", nil, err, "Layouts: %v.", l) &&
+			handleReturn(t, "SetRegion", FromNested([][]float64{{1, 2, 3}, {4, 7, 8}}),
+				dst.ToLayout(ColumnMajor), "Layouts: %v.", l)
+	}
+
+	// CopyFrom also copies values by point
+	dst := NewTensor(col.Dims)
+	err := dst.CopyFromSafe(row)
+	_ = handleErrors(t, "CopyFrom", nil, err, "RowMajor.") &&
+		handleReturn(t, "CopyFrom", col, dst, "RowMajor.")
+
+	// every other operation rejects Tensors that are not ColumnMajor
+	square := FromNested([][]float64{{1, 2}, {3, 4}}).ToLayout(RowMajor)
+	rejected := []struct {
+		name string
+		err  func() error
+	}{
+		{"MatMul", func() error { _, err := MatMulSafe(square, Eye(2)); return err }},
+		{"MatMul", func() error { _, err := MatMulSafe(Eye(2), square); return err }},
+		{"Transpose", func() error { _, err := square.TransposeSafe(); return err }},
+		{"Reshape", func() error { _, err := square.ReshapeSafe([]int{4}); return err }},
+		{"Concat", func() error { _, err := ConcatSafe(0, Eye(2), square); return err }},
+		{"Split", func() error { _, err := square.SplitSafe(0, []int{1, 1}); return err }},
+		{"Gather", func() error {
+			_, err := square.GatherSafe(0, NewIndices([]int{2, 2}, []int{0, 1, 1, 0}))
+			return err
+		}},
+		{"IndexSelect", func() error { _, err := square.IndexSelectSafe(0, []int{1}); return err }},
+		{"PadWith", func() error {
+			_, err := PadWithSafe(square, []int{1, 0}, []int{0, 0}, 0)
+			return err
+		}},
+		{"Crop", func() error { _, err := CropSafe(square, []int{1, 0}, []int{0, 0}); return err }},
+		{"Conv", func() error {
+			_, err := ConvSafe(square, Ones([]int{1, 1, 1}), nil)
+			return err
+		}},
+		{"MaxPool", func() error { _, _, err := square.MaxPoolSafe([]int{0}, nil); return err }},
+		{"LogSumExp", func() error { _, err := square.LogSumExpSafe(0); return err }},
+		{"LayerNorm", func() error { _, _, err := square.LayerNormSafe([]int{0}, 0); return err }},
+		{"MaskedSelect", func() error { _, err := square.MaskedSelectSafe(Eye(2)); return err }},
+		{"MSE", func() error { _, err := MSESafe(square, Eye(2), ReduceMean); return err }},
+		{"FFT", func() error { _, err := square.FFTSafe(0); return err }},
+		{"ToCSR", func() error { _, err := square.ToCSRSafe(); return err }},
+		{"Scatter", func() error {
+			dst := Eye(2)
+			return dst.ScatterSafe(0, NewIndices([]int{2, 2}, []int{0, 1, 1, 0}), square)
+		}},
+		{"COO.MulDense", func() error { _, err := Eye(2).ToCOO().MulDenseSafe(square); return err }},
+	}
+
+	for _, tab := range rejected {
+		err := tab.err()
+
+		var op OpError
+		if !errors.Is(err, ErrNotColumnMajor) {
+			t.Errorf("%s: RowMajor operand was not rejected. Got %q.", tab.name, err)
+		} else if !errors.As(err, &op) || op.Op() != tab.name {
+			t.Errorf("%s: Bad OpError. Got %q.", tab.name, err)
+		}
+	}
+
+	// functions that accept any Layout require their arguments to match
+	params := map[string]Tensor{"w": square.Clone()}
+	sgd := NewSGD(0.1, 0.9, false)
+	for i := 0; i < 2; i++ {
+		err := sgd.StepSafe(params, map[string]Tensor{"w": square})
+		handleErrors(t, "Optimizer", nil, err, "RowMajor, step %d.", i)
+	}
+
+	mismatched := []struct {
+		name string
+		err  error
+	}{
+		{"Optimizer", sgd.StepSafe(params, map[string]Tensor{"w": Eye(2)})},
+		{"BackwardWith", NewTape().Var(Eye(2)).BackwardWithSafe(square)},
+	}
+
+	for _, tab := range mismatched {
+		if !errors.Is(tab.err, ErrLayoutMismatch) {
+			t.Errorf("%s: Mismatched Layouts were not rejected. Got %q.", tab.name, tab.err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package linalg
+
+import (
+	"github.com/sharnoff/tensors"
+	"github.com/sharnoff/testdep"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	g := testdep.New()
+
+	// decomp_test.go
+	g.Require(tQR, tLU)
+	g.Require(tCholesky, tLU)
+
+	// solve_test.go
+	g.Require(tSolveTriangular)
+	g.Require(tSolve, tLU, tSolveTriangular)
+	g.Require(tInverse, tSolve)
+	g.Require(tDet, tLU)
+
+	// spectral_test.go
+	g.Require(tEigh, tLU)
+	g.Require(tSVD, tLU)
+	g.Require(tTopK, tEigh, tSVD)
+	g.Require(tPseudoInverse, tSVD, tInverse)
+
+	g.NameAll([]struct {
+		Fn   func(*testing.T)
+		Name string
+	}{
+		{tLU, "LU"},
+		{tCholesky, "Cholesky"},
+		{tQR, "QR"},
+		{tSolveTriangular, "SolveTriangular"},
+		{tSolve, "Solve"},
+		{tInverse, "Inverse"},
+		{tDet, "Det"},
+		{tEigh, "Eigh"},
+		{tSVD, "SVD"},
+		{tTopK, "TopK"},
+		{tPseudoInverse, "PseudoInverse"},
+	})
+
+	g.Test(t)
+}
+
+// returns true if there are no errors
+func handleErrors(t *testing.T, name string, expected, got error, format string, a ...interface{}) bool {
+	if expected == nil && got == nil {
+		return true
+	}
+
+	args := make([]interface{}, len(a), len(a)+2) // plus two for each of the errors
+	copy(args, a)
+	args = append(args, expected, got)
+
+	if format != "" {
+		format += " "
+	}
+	format += "Expected %q, Got %q."
+
+	if (expected == nil) != (got == nil) {
+		t.Errorf(name+": Whether or not error was returned did not match. "+format, args...)
+	} else if !Is(got, expected) {
+		t.Errorf(name+": Unexpected error type. "+format, args...)
+	}
+
+	return false
+}
+
+// returns true if the returns are equal
+func handleReturn(t *testing.T, name string, expected, got interface{}, format string, a ...interface{}) bool {
+	if reflect.DeepEqual(got, expected) {
+		return true
+	}
+
+	args := make([]interface{}, len(a), len(a)+2) // plus two for each of the errors
+	copy(args, a)
+	args = append(args, expected, got)
+
+	if format != "" {
+		format += " "
+	}
+	format += "Expected %v, Got %v."
+
+	t.Errorf(name+": Bad return. "+format, args...)
+	return false
+}
+
+func approxEqual(a, b tensors.Tensor, tol float64) bool {
+	if !tensors.Equals(a.Interpreter, b.Interpreter) {
+		return false
+	}
+
+	for i := range a.Values {
+		if !(math.Abs(a.Values[i]-b.Values[i]) <= tol) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// batchMatMul multiplies each pair of matrices in a and b, which must have matching batch
+// dimensions
+func batchMatMul(a, b tensors.Tensor) tensors.Tensor {
+	m, k, n := a.Dims[1], a.Dims[0], b.Dims[0]
+	out := tensors.NewTensor(append([]int{n, m}, a.Dims[2:]...))
+
+	for mat := 0; mat*m*n < len(out.Values); mat++ {
+		am, bm, om := matrix(a.Values, mat, m, k), matrix(b.Values, mat, k, n), matrix(out.Values, mat, m, n)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				for l := 0; l < k; l++ {
+					om[i*n+j] += am[i*k+l] * bm[l*n+j]
+				}
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,248 @@
+package linalg
+
+import (
+	"math"
+
+	"github.com/sharnoff/tensors"
+)
+
+// LU computes the LU decomposition of each square matrix in t, with partial pivoting, such that
+// P*A = L*U. The returned Tensor has the same dimensions as t, storing U on and above the
+// diagonal, and L (whose diagonal is all ones) below it.
+//
+// The returned Indices have dimensions [n, batch...], and record the row swaps that were made,
+// in order: row i of each matrix was swapped with row pivots[i] (which is always ≥ i).
+//
+// LU does not fail on singular matrices; their decomposition is returned with a zero on the
+// diagonal of U. LU will panic if any of the error conditions from LUSafe are met.
+func LU(t tensors.Tensor) (tensors.Tensor, tensors.Indices) {
+	lu, pivots, err := LUSafe(t)
+	if err != nil {
+		panic(err)
+	}
+
+	return lu, pivots
+}
+
+// LUSafe undergoes the same process as LU, but returns error instead of panicking. LUSafe returns
+// RankError if t has fewer than two dimensions, and NotSquareError if its matrices are not square.
+func LUSafe(t tensors.Tensor) (tensors.Tensor, tensors.Indices, error) {
+	b, err := squareBatchOf(t)
+	if err != nil {
+		return tensors.Tensor{}, tensors.Indices{}, err
+	}
+
+	n := b.rows
+	lu := tensors.NewTensor(t.Dims)
+	copy(lu.Values, t.Values)
+
+	pivots := make([]int, n*b.count)
+	for i := 0; i < b.count; i++ {
+		luInPlace(matrix(lu.Values, i, n, n), pivots[i*n:(i+1)*n], n)
+	}
+
+	return lu, tensors.NewIndices(append([]int{n}, b.dims...), pivots), nil
+}
+
+// luInPlace replaces the n×n matrix m with its LU decomposition, storing the row swaps in pivots.
+// It returns the sign of the permutation, and the index of the first zero pivot (or -1 if there
+// were none).
+func luInPlace(m []float64, pivots []int, n int) (sign float64, singular int) {
+	sign, singular = 1, -1
+
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(m[i*n+k]) > math.Abs(m[p*n+k]) {
+				p = i
+			}
+		}
+
+		pivots[k] = p
+		if p != k {
+			sign = -sign
+			for j := 0; j < n; j++ {
+				m[k*n+j], m[p*n+j] = m[p*n+j], m[k*n+j]
+			}
+		}
+
+		pivot := m[k*n+k]
+		if pivot == 0 {
+			if singular == -1 {
+				singular = k
+			}
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			m[i*n+k] /= pivot
+			f := m[i*n+k]
+			for j := k + 1; j < n; j++ {
+				m[i*n+j] -= f * m[k*n+j]
+			}
+		}
+	}
+
+	return sign, singular
+}
+
+// Cholesky computes the Cholesky decomposition of each symmetric, positive-definite matrix in t,
+// returning the lower-triangular L such that A = L*Lᵀ. Only the lower triangle of each matrix is
+// read; symmetry is assumed, not checked.
+//
+// Cholesky will panic if any of the error conditions from CholeskySafe are met.
+func Cholesky(t tensors.Tensor) tensors.Tensor {
+	l, err := CholeskySafe(t)
+	if err != nil {
+		panic(err)
+	}
+
+	return l
+}
+
+// CholeskySafe undergoes the same process as Cholesky, but returns error instead of panicking.
+// CholeskySafe returns RankError if t has fewer than two dimensions, NotSquareError if its
+// matrices are not square, and a NotPosDefError if any matrix is not positive definite.
+func CholeskySafe(t tensors.Tensor) (tensors.Tensor, error) {
+	b, err := squareBatchOf(t)
+	if err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	n := b.rows
+	l := tensors.NewTensor(t.Dims)
+
+	for m := 0; m < b.count; m++ {
+		a, lm := matrix(t.Values, m, n, n), matrix(l.Values, m, n, n)
+
+		for j := 0; j < n; j++ {
+			d := a[j*n+j]
+			for k := 0; k < j; k++ {
+				d -= lm[j*n+k] * lm[j*n+k]
+			}
+
+			if !(d > 0) {
+				return tensors.Tensor{}, NotPosDefError{m, j}
+			}
+
+			lm[j*n+j] = math.Sqrt(d)
+
+			for i := j + 1; i < n; i++ {
+				s := a[i*n+j]
+				for k := 0; k < j; k++ {
+					s -= lm[i*n+k] * lm[j*n+k]
+				}
+
+				lm[i*n+j] = s / lm[j*n+j]
+			}
+		}
+	}
+
+	return l, nil
+}
+
+// QR computes the reduced QR decomposition of each matrix in t with Householder reflections. For
+// an m×n matrix A, with k = min(m, n), QR returns the m×k matrix Q with orthonormal columns, and
+// the k×n upper-triangular matrix R, such that A = Q*R.
+//
+// QR will panic if t has fewer than two dimensions. QRSafe returns RankError instead.
+func QR(t tensors.Tensor) (tensors.Tensor, tensors.Tensor) {
+	q, r, err := QRSafe(t)
+	if err != nil {
+		panic(err)
+	}
+
+	return q, r
+}
+
+// QRSafe undergoes the same process as QR, but returns error instead of panicking.
+func QRSafe(t tensors.Tensor) (tensors.Tensor, tensors.Tensor, error) {
+	b, err := batchOf(t)
+	if err != nil {
+		return tensors.Tensor{}, tensors.Tensor{}, err
+	}
+
+	m, n := b.rows, b.cols
+	k := m
+	if n < k {
+		k = n
+	}
+
+	q := tensors.NewTensor(b.tensorDims(m, k))
+	r := tensors.NewTensor(b.tensorDims(k, n))
+
+	work := make([]float64, m*n)
+	vs := make([][]float64, k)
+	for i := range vs {
+		vs[i] = make([]float64, m-i)
+	}
+
+	for mat := 0; mat < b.count; mat++ {
+		copy(work, matrix(t.Values, mat, m, n))
+
+		for j := 0; j < k; j++ {
+			// build the Householder vector that zeros column j below the diagonal
+			v := vs[j]
+			norm := 0.0
+			for i := range v {
+				v[i] = work[(j+i)*n+j]
+				norm += v[i] * v[i]
+			}
+
+			norm = math.Sqrt(norm)
+			if v[0] < 0 {
+				norm = -norm
+			}
+
+			v[0] += norm
+			householder(work, v, j, n, j, n)
+		}
+
+		rm := matrix(r.Values, mat, k, n)
+		for i := 0; i < k; i++ {
+			for j := i; j < n; j++ {
+				rm[i*n+j] = work[i*n+j]
+			}
+		}
+
+		// Q is the product of the reflections, applied to the first k columns of the identity
+		qm := matrix(q.Values, mat, m, k)
+		for i := range qm {
+			qm[i] = 0
+		}
+		for i := 0; i < k; i++ {
+			qm[i*k+i] = 1
+		}
+
+		for j := k - 1; j >= 0; j-- {
+			householder(qm, vs[j], j, k, 0, k)
+		}
+	}
+
+	return q, r, nil
+}
+
+// householder applies the Householder reflection I - 2vvᵀ/(vᵀv) to the rows of m (which has width
+// cols) starting at row, in columns [from, to).
+func householder(m, v []float64, row, cols, from, to int) {
+	vv := 0.0
+	for _, x := range v {
+		vv += x * x
+	}
+
+	if vv == 0 {
+		return
+	}
+
+	for c := from; c < to; c++ {
+		dot := 0.0
+		for i, x := range v {
+			dot += x * m[(row+i)*cols+c]
+		}
+
+		f := 2 * dot / vv
+		for i, x := range v {
+			m[(row+i)*cols+c] -= f * x
+		}
+	}
+}
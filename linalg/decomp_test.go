@@ -0,0 +1,145 @@
+package linalg
+
+import (
+	"errors"
+	"github.com/sharnoff/tensors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func tLU(t *testing.T) {
+	lu, pivots, err := LUSafe(tensors.FromNested([][]float64{{4, 3}, {6, 3}}))
+	if handleErrors(t, "LU", nil, err, "") {
+		handleReturn(t, "LU", tensors.FromNested([][]float64{{6, 3}, {2.0 / 3, 1}}), lu, "")
+		handleReturn(t, "LU", tensors.NewIndices([]int{2}, []int{1, 1}), pivots, "")
+	}
+
+	// reconstruct P*A = L*U for a batch of random matrices
+	rng := rand.New(rand.NewSource(1))
+	a := tensors.NewRandom([]int{4, 4, 3}, tensors.Uniform(-1, 1), rng)
+	lu, pivots = LU(a)
+
+	l, u := tensors.NewTensor(a.Dims), tensors.NewTensor(a.Dims)
+	pa := tensors.NewTensor(a.Dims)
+	copy(pa.Values, a.Values)
+
+	for m := 0; m < 3; m++ {
+		lm, um, lum := matrix(l.Values, m, 4, 4), matrix(u.Values, m, 4, 4), matrix(lu.Values, m, 4, 4)
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				switch {
+				case j < i:
+					lm[i*4+j] = lum[i*4+j]
+				case j == i:
+					lm[i*4+j], um[i*4+j] = 1, lum[i*4+j]
+				default:
+					um[i*4+j] = lum[i*4+j]
+				}
+			}
+		}
+
+		pm := matrix(pa.Values, m, 4, 4)
+		for i, p := range pivots.Values[m*4 : (m+1)*4] {
+			for j := 0; j < 4; j++ {
+				pm[i*4+j], pm[p*4+j] = pm[p*4+j], pm[i*4+j]
+			}
+		}
+	}
+
+	if res := batchMatMul(l, u); !approxEqual(pa, res, 1e-12) {
+		t.Errorf("LU: L*U does not match P*A. Expected %v, Got %v.", pa, res)
+	}
+
+	errTable := []struct {
+		dims []int
+		err  error
+	}{
+		{[]int{3}, RankError{}},
+		{[]int{3, 2}, NotSquareError{}},
+		{[]int{2, 3, 2}, NotSquareError{}},
+	}
+
+	for _, tab := range errTable {
+		_, _, err := LUSafe(tensors.NewTensor(tab.dims))
+		handleErrors(t, "LU", tab.err, err, "Dims: %v.", tab.dims)
+	}
+}
+
+// requires LU
+func tCholesky(t *testing.T) {
+	l, err := CholeskySafe(tensors.FromNested([][][]float64{
+		{{4, 2}, {2, 3}},
+		{{1, 0}, {0, 9}},
+	}))
+
+	expected := tensors.FromNested([][][]float64{
+		{{2, 0}, {1, math.Sqrt(2)}},
+		{{1, 0}, {0, 3}},
+	})
+
+	if handleErrors(t, "Cholesky", nil, err, "") && !approxEqual(expected, l, 1e-12) {
+		t.Errorf("Cholesky: Bad values. Expected %v, Got %v.", expected, l)
+	}
+
+	_, err = CholeskySafe(tensors.FromNested([][][]float64{{{1, 0}, {0, 1}}, {{1, 2}, {2, 1}}}))
+	var npd NotPosDefError
+	if handleErrors(t, "Cholesky", NotPosDefError{}, err, "Indefinite matrix.") &&
+		(!errors.As(err, &npd) || npd.Matrix() != 1 || npd.Pivot() != 1) {
+		t.Errorf("Cholesky: Expected matrix 1, pivot 1 to be reported. Got %q.", err)
+	}
+
+	_, err = CholeskySafe(tensors.NewTensor([]int{2, 3}))
+	handleErrors(t, "Cholesky", NotSquareError{}, err, "Dims: [2 3].")
+}
+
+// requires LU
+func tQR(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for _, dims := range [][]int{{3, 5}, {5, 3}, {4, 4, 2}} {
+		a := tensors.NewRandom(dims, tensors.Uniform(-1, 1), rng)
+		q, r, err := QRSafe(a)
+		if !handleErrors(t, "QR", nil, err, "Dims: %v.", dims) {
+			continue
+		}
+
+		if res := batchMatMul(q, r); !approxEqual(a, res, 1e-12) {
+			t.Errorf("QR: Q*R does not match A. Dims: %v. Expected %v, Got %v.", dims, a, res)
+		}
+
+		k := q.Dims[0]
+		for m := 0; m*r.Dims[0]*k < len(r.Values); m++ {
+			rm := matrix(r.Values, m, k, r.Dims[0])
+			for i := 0; i < k; i++ {
+				for j := 0; j < i; j++ {
+					if rm[i*r.Dims[0]+j] != 0 {
+						t.Errorf("QR: R is not upper-triangular. Dims: %v. Got %v.", dims, r)
+					}
+				}
+			}
+
+			// QᵀQ = I
+			qm := matrix(q.Values, m, q.Dims[1], k)
+			for i := 0; i < k; i++ {
+				for j := 0; j < k; j++ {
+					dot := 0.0
+					for row := 0; row < q.Dims[1]; row++ {
+						dot += qm[row*k+i] * qm[row*k+j]
+					}
+
+					if i == j {
+						dot--
+					}
+
+					if math.Abs(dot) > 1e-12 {
+						t.Errorf("QR: Q is not orthonormal. Dims: %v. Got %v.", dims, q)
+					}
+				}
+			}
+		}
+	}
+
+	_, _, err := QRSafe(tensors.NewTensor([]int{4}))
+	handleErrors(t, "QR", RankError{}, err, "Dims: [4].")
+}
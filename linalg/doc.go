@@ -0,0 +1,11 @@
+// Package linalg provides dense linear algebra for Tensors from github.com/sharnoff/tensors.
+//
+// Every function in this package interprets its Tensors as matrices in the same way that
+// tensors.MatMul and tensors.FromNested do: a Tensor with dimensions [cols, rows] is a matrix with
+// rows*cols values, stored row by row. Any further dimensions are treated as a batch, so that a
+// Tensor with dimensions [n, n, b] holds b separate n×n matrices, each of which is operated on
+// independently. Tensors must have the ColumnMajor Layout; any others are rejected with
+// tensors.ErrNotColumnMajor, and may be converted first with ToLayout.
+//
+// As with the parent package, functions panic on errors, and 'Safe' variants return them instead.
+package linalg
@@ -0,0 +1,132 @@
+package linalg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// RankError serves to document errors from Tensors with fewer than two dimensions, which cannot be
+// interpreted as matrices.
+type RankError struct {
+	dims []int
+}
+
+// NotSquareError serves to document errors from matrices that are required to be square, but are
+// not.
+type NotSquareError struct {
+	dims []int
+}
+
+// SingularError serves to document errors from singular matrices. matrix is the index of the
+// matrix within its batch, and pivot the diagonal position at which elimination failed.
+type SingularError struct {
+	matrix, pivot int
+}
+
+// NotPosDefError serves to document errors from matrices that are required to be positive
+// definite, but are not. matrix is the index of the matrix within its batch, and pivot the
+// diagonal position at which the decomposition failed.
+type NotPosDefError struct {
+	matrix, pivot int
+}
+
+// ShapeMismatchError serves to document errors from operands whose dimensions are incompatible
+// with one another.
+type ShapeMismatchError struct {
+	dims, shouldBe []int
+}
+
+func (err RankError) Error() string {
+	return fmt.Sprintf("dims %v must have at least 2 dimensions", err.dims)
+}
+
+func (err NotSquareError) Error() string {
+	return fmt.Sprintf("matrix with dims %v is not square", err.dims)
+}
+
+func (err SingularError) Error() string {
+	return fmt.Sprintf("matrix %d is singular (zero pivot at %d)", err.matrix, err.pivot)
+}
+
+func (err NotPosDefError) Error() string {
+	return fmt.Sprintf("matrix %d is not positive definite (non-positive pivot at %d)", err.matrix,
+		err.pivot)
+}
+
+func (err ShapeMismatchError) Error() string {
+	return fmt.Sprintf("dims %v do not match %v", err.dims, err.shouldBe)
+}
+
+// Is methods allow errors.Is to identify errors from this package by type, as Is does.
+func (err RankError) Is(target error) bool {
+	_, ok := target.(RankError)
+	return ok
+}
+
+func (err NotSquareError) Is(target error) bool {
+	_, ok := target.(NotSquareError)
+	return ok
+}
+
+func (err SingularError) Is(target error) bool {
+	_, ok := target.(SingularError)
+	return ok
+}
+
+func (err NotPosDefError) Is(target error) bool {
+	_, ok := target.(NotPosDefError)
+	return ok
+}
+
+func (err ShapeMismatchError) Is(target error) bool {
+	_, ok := target.(ShapeMismatchError)
+	return ok
+}
+
+// Dims returns the dimensions that could not be interpreted as matrices.
+func (err RankError) Dims() []int { return err.dims }
+
+// Dims returns the dimensions of the matrix that was not square.
+func (err NotSquareError) Dims() []int { return err.dims }
+
+// Matrix returns the index of the singular matrix within its batch.
+func (err SingularError) Matrix() int { return err.matrix }
+
+// Pivot returns the diagonal position at which elimination failed.
+func (err SingularError) Pivot() int { return err.pivot }
+
+// Matrix returns the index of the matrix that is not positive definite within its batch.
+func (err NotPosDefError) Matrix() int { return err.matrix }
+
+// Pivot returns the diagonal position at which the decomposition failed.
+func (err NotPosDefError) Pivot() int { return err.pivot }
+
+// Dims returns the dimensions of the offending operand.
+func (err ShapeMismatchError) Dims() []int { return err.dims }
+
+// ShouldBe returns the dimensions that the offending operand was expected to match.
+func (err ShapeMismatchError) ShouldBe() []int { return err.shouldBe }
+
+// Is checks whether or not two errors from this package are the same type, in the same manner as
+// tensors.Is: for type Error, Is checks individual variables, and for other types Is performs a
+// type comparison. Wrapped errors are unwrapped, as with errors.Is.
+func Is(err, base error) bool {
+	if errors.Is(err, base) {
+		return true
+	} else if _, ok := base.(Error); ok {
+		return false
+	}
+
+	return reflect.TypeOf(base) == reflect.TypeOf(err)
+}
+
+// Error is a placeholder for specific errors and their messages, all of which are stored as vars
+type Error struct{ string }
+
+func (e Error) Error() string { return e.string }
+
+var (
+	ErrNoConverge = Error{"iteration did not converge"}
+	ErrTopK       = Error{"k is not within the number of values"}
+)
@@ -0,0 +1,87 @@
+package linalg
+
+import (
+	"github.com/sharnoff/tensors"
+)
+
+// batch describes a Tensor interpreted as a batch of matrices
+type batch struct {
+	rows, cols int
+
+	// count is the number of matrices in the batch
+	count int
+
+	// dims are the dimensions of the batch, excluding the matrix dimensions
+	dims []int
+}
+
+// batchOf returns the batch described by t, RankError if t has fewer than two dimensions, or
+// tensors.ErrNotColumnMajor if t does not have the ColumnMajor Layout.
+func batchOf(t tensors.Tensor) (batch, error) {
+	if len(t.Dims) < 2 {
+		return batch{}, RankError{t.Dims}
+	} else if t.Layout != tensors.ColumnMajor {
+		return batch{}, tensors.ErrNotColumnMajor
+	}
+
+	b := batch{rows: t.Dims[1], cols: t.Dims[0], count: 1, dims: t.Dims[2:]}
+	for _, d := range b.dims {
+		b.count *= d
+	}
+
+	return b, nil
+}
+
+// squareBatchOf returns the batch described by t, which must be made of square matrices.
+func squareBatchOf(t tensors.Tensor) (batch, error) {
+	b, err := batchOf(t)
+	if err != nil {
+		return b, err
+	} else if b.rows != b.cols {
+		return b, NotSquareError{t.Dims}
+	}
+
+	return b, nil
+}
+
+// tensorDims returns the dimensions of a Tensor holding a batch of the same size, with rows*cols
+// matrices.
+func (b batch) tensorDims(rows, cols int) []int {
+	return append([]int{cols, rows}, b.dims...)
+}
+
+// matrix returns the values of the i'th matrix in the batch, given the values of the entire
+// batch, with size rows*cols.
+func matrix(values []float64, i, rows, cols int) []float64 {
+	return values[i*rows*cols : (i+1)*rows*cols]
+}
+
+// checkRHS checks that b is a valid right-hand side for the square batch a, returning the batch
+// described by b.
+func checkRHS(a batch, b tensors.Tensor) (batch, error) {
+	bb, err := batchOf(b)
+	if err != nil {
+		return bb, err
+	}
+
+	shouldBe := a.tensorDims(a.rows, bb.cols)
+	if !equalInts(b.Dims, shouldBe) {
+		return bb, ShapeMismatchError{b.Dims, shouldBe}
+	}
+
+	return bb, nil
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,240 @@
+package linalg
+
+import (
+	"github.com/sharnoff/tensors"
+)
+
+// SolveTriangular solves A*X = B for X, where each matrix in a is triangular -- lower-triangular
+// if lower is true, and upper-triangular otherwise. Values on the other side of the diagonal are
+// ignored. b must have dimensions [k, n, batch...], where a has dimensions [n, n, batch...];
+// ie. each matrix in b has k columns.
+//
+// SolveTriangular will panic if any of the error conditions from SolveTriangularSafe are met.
+func SolveTriangular(a, b tensors.Tensor, lower bool) tensors.Tensor {
+	x, err := SolveTriangularSafe(a, b, lower)
+	if err != nil {
+		panic(err)
+	}
+
+	return x
+}
+
+// SolveTriangularSafe undergoes the same process as SolveTriangular, but returns error instead of
+// panicking. SolveTriangularSafe returns:
+//		(0) RankError if either a or b has fewer than two dimensions
+//		(1) NotSquareError if the matrices in a are not square
+//		(2) ShapeMismatchError if b does not match a
+//		(3) SingularError if any value on the diagonal of a is zero
+func SolveTriangularSafe(a, b tensors.Tensor, lower bool) (tensors.Tensor, error) {
+	ab, err := squareBatchOf(a)
+	if err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	bb, err := checkRHS(ab, b)
+	if err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	n, k := ab.rows, bb.cols
+	x := tensors.NewTensor(b.Dims)
+	copy(x.Values, b.Values)
+
+	for m := 0; m < ab.count; m++ {
+		am, xm := matrix(a.Values, m, n, n), matrix(x.Values, m, n, k)
+		if i := substitute(am, xm, n, k, lower, false); i != -1 {
+			return tensors.Tensor{}, SingularError{m, i}
+		}
+	}
+
+	return x, nil
+}
+
+// substitute solves the triangular system a*x = b in place, where b is stored in x. If unit is
+// true, the diagonal of a is assumed to be all ones. substitute returns the first zero on the
+// diagonal, or -1 if there were none.
+func substitute(a, x []float64, n, k int, lower, unit bool) int {
+	for step := 0; step < n; step++ {
+		i := step
+		if !lower {
+			i = n - 1 - step
+		}
+
+		d := 1.0
+		if !unit {
+			d = a[i*n+i]
+			if d == 0 {
+				return i
+			}
+		}
+
+		for c := 0; c < k; c++ {
+			s := x[i*k+c]
+			if lower {
+				for j := 0; j < i; j++ {
+					s -= a[i*n+j] * x[j*k+c]
+				}
+			} else {
+				for j := i + 1; j < n; j++ {
+					s -= a[i*n+j] * x[j*k+c]
+				}
+			}
+
+			x[i*k+c] = s / d
+		}
+	}
+
+	return -1
+}
+
+// Solve solves A*X = B for X using the LU decomposition of a. a must have dimensions
+// [n, n, batch...], and b [k, n, batch...]; the returned Tensor has the same dimensions as b.
+//
+// Solve will panic if any of the error conditions from SolveSafe are met.
+func Solve(a, b tensors.Tensor) tensors.Tensor {
+	x, err := SolveSafe(a, b)
+	if err != nil {
+		panic(err)
+	}
+
+	return x
+}
+
+// SolveSafe undergoes the same process as Solve, but returns error instead of panicking.
+// SolveSafe returns:
+//		(0) RankError if either a or b has fewer than two dimensions
+//		(1) NotSquareError if the matrices in a are not square
+//		(2) ShapeMismatchError if b does not match a
+//		(3) SingularError if any matrix in a is singular
+func SolveSafe(a, b tensors.Tensor) (tensors.Tensor, error) {
+	ab, err := squareBatchOf(a)
+	if err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	bb, err := checkRHS(ab, b)
+	if err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	x := tensors.NewTensor(b.Dims)
+	copy(x.Values, b.Values)
+
+	if err := solveInPlace(ab, a.Values, x.Values, bb.cols); err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	return x, nil
+}
+
+// solveInPlace solves each system in the batch, overwriting x (which initially stores b, with k
+// columns).
+func solveInPlace(ab batch, a, x []float64, k int) error {
+	n := ab.rows
+	lu := make([]float64, n*n)
+	pivots := make([]int, n)
+
+	for m := 0; m < ab.count; m++ {
+		copy(lu, matrix(a, m, n, n))
+		if _, s := luInPlace(lu, pivots, n); s != -1 {
+			return SingularError{m, s}
+		}
+
+		xm := matrix(x, m, n, k)
+		for i, p := range pivots {
+			if p != i {
+				for c := 0; c < k; c++ {
+					xm[i*k+c], xm[p*k+c] = xm[p*k+c], xm[i*k+c]
+				}
+			}
+		}
+
+		substitute(lu, xm, n, k, true, true)
+		substitute(lu, xm, n, k, false, false)
+	}
+
+	return nil
+}
+
+// Inverse returns the inverse of each matrix in t.
+//
+// Inverse will panic if any of the error conditions from InverseSafe are met.
+func Inverse(t tensors.Tensor) tensors.Tensor {
+	inv, err := InverseSafe(t)
+	if err != nil {
+		panic(err)
+	}
+
+	return inv
+}
+
+// InverseSafe undergoes the same process as Inverse, but returns error instead of panicking.
+// InverseSafe returns RankError if t has fewer than two dimensions, NotSquareError if its
+// matrices are not square, and SingularError if any of them is singular.
+func InverseSafe(t tensors.Tensor) (tensors.Tensor, error) {
+	b, err := squareBatchOf(t)
+	if err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	n := b.rows
+	inv := tensors.NewTensor(t.Dims)
+	for m := 0; m < b.count; m++ {
+		im := matrix(inv.Values, m, n, n)
+		for i := 0; i < n; i++ {
+			im[i*n+i] = 1
+		}
+	}
+
+	if err := solveInPlace(b, t.Values, inv.Values, n); err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	return inv, nil
+}
+
+// Det returns the determinant of each matrix in t. The returned Tensor has the batch dimensions
+// of t, or the dimensions [1] if t is a single matrix. Singular matrices have a determinant of
+// zero; they do not cause errors.
+//
+// Det will panic if any of the error conditions from DetSafe are met.
+func Det(t tensors.Tensor) tensors.Tensor {
+	det, err := DetSafe(t)
+	if err != nil {
+		panic(err)
+	}
+
+	return det
+}
+
+// DetSafe undergoes the same process as Det, but returns error instead of panicking. DetSafe
+// returns RankError if t has fewer than two dimensions, and NotSquareError if its matrices are not
+// square.
+func DetSafe(t tensors.Tensor) (tensors.Tensor, error) {
+	b, err := squareBatchOf(t)
+	if err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	dims := b.dims
+	if len(dims) == 0 {
+		dims = []int{1}
+	}
+
+	n := b.rows
+	det := tensors.NewTensor(dims)
+	lu := make([]float64, n*n)
+	pivots := make([]int, n)
+
+	for m := 0; m < b.count; m++ {
+		copy(lu, matrix(t.Values, m, n, n))
+		d, _ := luInPlace(lu, pivots, n)
+		for i := 0; i < n; i++ {
+			d *= lu[i*n+i]
+		}
+
+		det.Values[m] = d
+	}
+
+	return det, nil
+}
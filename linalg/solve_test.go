@@ -0,0 +1,100 @@
+package linalg
+
+import (
+	"github.com/sharnoff/tensors"
+	"math/rand"
+	"testing"
+)
+
+func tSolveTriangular(t *testing.T) {
+	table := []struct {
+		a, b  [][]float64
+		lower bool
+		x     [][]float64
+		err   error
+	}{
+		{[][]float64{{2, 9}, {1, 1}}, [][]float64{{2}, {3}}, true, [][]float64{{1}, {2}}, nil},
+		{[][]float64{{2, 1}, {9, 4}}, [][]float64{{4, 2}, {8, 4}}, false, [][]float64{{1, 0.5}, {2, 1}}, nil},
+
+		{[][]float64{{0, 0}, {1, 1}}, [][]float64{{1}, {1}}, true, nil, SingularError{}},
+		{[][]float64{{1, 0}, {1, 1}}, [][]float64{{1, 1, 1}}, true, nil, ShapeMismatchError{}},
+	}
+
+	for _, tab := range table {
+		a, b := tensors.FromNested(tab.a), tensors.FromNested(tab.b)
+		x, err := SolveTriangularSafe(a, b, tab.lower)
+
+		format := "A: %v, B: %v, Lower: %v."
+		if handleErrors(t, "SolveTriangular", tab.err, err, format, tab.a, tab.b, tab.lower) {
+			handleReturn(t, "SolveTriangular", tensors.FromNested(tab.x), x, format, tab.a, tab.b, tab.lower)
+		}
+	}
+}
+
+// requires LU, SolveTriangular
+func tSolve(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	a := tensors.NewRandom([]int{4, 4, 2}, tensors.Uniform(-1, 1), rng)
+	b := tensors.NewRandom([]int{3, 4, 2}, tensors.Uniform(-1, 1), rng)
+
+	x, err := SolveSafe(a, b)
+	if handleErrors(t, "Solve", nil, err, "") {
+		if res := batchMatMul(a, x); !approxEqual(b, res, 1e-10) {
+			t.Errorf("Solve: A*X does not match B. Expected %v, Got %v.", b, res)
+		}
+	}
+
+	rowMajor := tensors.Eye(2).ToLayout(tensors.RowMajor)
+	errTable := []struct {
+		a, b tensors.Tensor
+		err  error
+	}{
+		{tensors.FromNested([][]float64{{1, 2}, {2, 4}}), tensors.NewTensor([]int{1, 2}), SingularError{}},
+		{tensors.NewTensor([]int{3, 2}), tensors.NewTensor([]int{1, 2}), NotSquareError{}},
+		{tensors.NewTensor([]int{2, 2}), tensors.NewTensor([]int{2}), RankError{}},
+		{tensors.NewTensor([]int{2, 2}), tensors.NewTensor([]int{1, 3}), ShapeMismatchError{}},
+		{tensors.NewTensor([]int{2, 2, 2}), tensors.NewTensor([]int{1, 2, 3}), ShapeMismatchError{}},
+		{rowMajor, tensors.NewTensor([]int{1, 2}), tensors.ErrNotColumnMajor},
+		{tensors.Eye(2), rowMajor, tensors.ErrNotColumnMajor},
+	}
+
+	for _, tab := range errTable {
+		_, err := SolveSafe(tab.a, tab.b)
+		handleErrors(t, "Solve", tab.err, err, "A: %v, B: %v.", tab.a.Dims, tab.b.Dims)
+	}
+}
+
+// requires Solve
+func tInverse(t *testing.T) {
+	inv, err := InverseSafe(tensors.FromNested([][]float64{{4, 7}, {2, 6}}))
+	expected := tensors.FromNested([][]float64{{0.6, -0.7}, {-0.2, 0.4}})
+	if handleErrors(t, "Inverse", nil, err, "") && !approxEqual(expected, inv, 1e-12) {
+		t.Errorf("Inverse: Bad values. Expected %v, Got %v.", expected, inv)
+	}
+
+	_, err = InverseSafe(tensors.FromNested([][]float64{{1, 2}, {2, 4}}))
+	handleErrors(t, "Inverse", SingularError{}, err, "Singular matrix.")
+}
+
+// requires LU
+func tDet(t *testing.T) {
+	table := []struct {
+		a   tensors.Tensor
+		det tensors.Tensor
+	}{
+		{tensors.FromNested([][]float64{{1, 2}, {3, 4}}), tensors.FromNested([]float64{-2})},
+		{tensors.FromNested([][]float64{{1, 2}, {2, 4}}), tensors.FromNested([]float64{0})},
+		{tensors.FromNested([][][]float64{{{0, 1, 0}, {1, 0, 0}, {0, 0, 5}}, {{2, 0, 0}, {0, 3, 0}, {0, 0, 1}}}),
+			tensors.FromNested([]float64{-5, 6})},
+	}
+
+	for _, tab := range table {
+		det, err := DetSafe(tab.a)
+		if handleErrors(t, "Det", nil, err, "A: %v.", tab.a) && !approxEqual(tab.det, det, 1e-12) {
+			t.Errorf("Det: Bad values. A: %v. Expected %v, Got %v.", tab.a, tab.det, det)
+		}
+	}
+
+	_, err := DetSafe(tensors.NewTensor([]int{2, 3}))
+	handleErrors(t, "Det", NotSquareError{}, err, "Dims: [2 3].")
+}
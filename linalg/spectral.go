@@ -0,0 +1,458 @@
+package linalg
+
+import (
+	"math"
+	"sort"
+
+	"github.com/sharnoff/tensors"
+)
+
+// maxSweeps is the number of Jacobi sweeps after which Eigh and SVD give up, returning
+// ErrNoConverge. Both typically converge in fewer than 10.
+const maxSweeps = 100
+
+// Eigh computes the eigendecomposition of each symmetric matrix in t with the cyclic Jacobi
+// method, returning the eigenvalues and the eigenvectors, such that A = V*diag(w)*Vᵀ.
+//
+// The eigenvalues have dimensions [n, batch...], and are sorted in descending order. The
+// eigenvectors have the same dimensions as t, with each column of V being the unit eigenvector
+// for the corresponding eigenvalue. Symmetry is assumed, not checked.
+//
+// Eigh will panic if any of the error conditions from EighSafe are met.
+func Eigh(t tensors.Tensor) (tensors.Tensor, tensors.Tensor) {
+	w, v, err := EighSafe(t)
+	if err != nil {
+		panic(err)
+	}
+
+	return w, v
+}
+
+// EighSafe undergoes the same process as Eigh, but returns error instead of panicking. EighSafe
+// returns RankError if t has fewer than two dimensions, NotSquareError if its matrices are not
+// square, and ErrNoConverge if the iteration does not converge.
+func EighSafe(t tensors.Tensor) (tensors.Tensor, tensors.Tensor, error) {
+	b, err := squareBatchOf(t)
+	if err != nil {
+		return tensors.Tensor{}, tensors.Tensor{}, err
+	}
+
+	n := b.rows
+	w := tensors.NewTensor(append([]int{n}, b.dims...))
+	v := tensors.NewTensor(t.Dims)
+	a := make([]float64, n*n)
+
+	for m := 0; m < b.count; m++ {
+		copy(a, matrix(t.Values, m, n, n))
+		vm := matrix(v.Values, m, n, n)
+		if !jacobiEigen(a, vm, n) {
+			return tensors.Tensor{}, tensors.Tensor{}, ErrNoConverge
+		}
+
+		wm := w.Values[m*n : (m+1)*n]
+		for i := range wm {
+			wm[i] = a[i*n+i]
+		}
+
+		sortColumns(wm, vm, n)
+	}
+
+	return w, v, nil
+}
+
+// jacobiEigen diagonalizes the n×n symmetric matrix a in place, storing the accumulated rotations
+// in v. It returns false if the iteration did not converge.
+func jacobiEigen(a, v []float64, n int) bool {
+	for i := range v {
+		v[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1
+	}
+
+	total := 0.0
+	for _, x := range a {
+		total += x * x
+	}
+
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		off := 0.0
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += a[p*n+q] * a[p*n+q]
+			}
+		}
+
+		if off <= 1e-32*total {
+			return true
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p*n+q] == 0 {
+					continue
+				}
+
+				c, s := rotation(a[p*n+p], a[q*n+q], a[p*n+q])
+				rotateColumns(a, n, n, p, q, c, s)
+				rotateRows(a, n, p, q, c, s)
+				rotateColumns(v, n, n, p, q, c, s)
+			}
+		}
+	}
+
+	return false
+}
+
+// rotation returns the cosine and sine of the Jacobi rotation that zeros the off-diagonal value
+// gamma, given the diagonal values alpha and beta.
+func rotation(alpha, beta, gamma float64) (c, s float64) {
+	zeta := (beta - alpha) / (2 * gamma)
+	t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+	if zeta < 0 {
+		t = -t
+	}
+
+	c = 1 / math.Sqrt(1+t*t)
+	return c, c * t
+}
+
+// rotateColumns applies a rotation to columns p and q of m, which has the given number of rows
+// and columns.
+func rotateColumns(m []float64, rows, cols, p, q int, c, s float64) {
+	for i := 0; i < rows; i++ {
+		mp, mq := m[i*cols+p], m[i*cols+q]
+		m[i*cols+p] = c*mp - s*mq
+		m[i*cols+q] = s*mp + c*mq
+	}
+}
+
+// rotateRows applies a rotation to rows p and q of m, which has the given number of columns.
+func rotateRows(m []float64, cols, p, q int, c, s float64) {
+	for j := 0; j < cols; j++ {
+		mp, mq := m[p*cols+j], m[q*cols+j]
+		m[p*cols+j] = c*mp - s*mq
+		m[q*cols+j] = s*mp + c*mq
+	}
+}
+
+// sortColumns sorts values in descending order, applying the same permutation to the columns of
+// m (which has the given number of rows) and each of others. All have len(values) columns.
+func sortColumns(values []float64, m []float64, rows int, others ...[]float64) {
+	k := len(values)
+	order := make([]int, k)
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] > values[order[j]] })
+
+	permute := func(dst []float64, rows int) {
+		src := make([]float64, len(dst))
+		copy(src, dst)
+		for i := 0; i < rows; i++ {
+			for j, o := range order {
+				dst[i*k+j] = src[i*k+o]
+			}
+		}
+	}
+
+	permute(values, 1)
+	permute(m, rows)
+	for _, o := range others {
+		permute(o, len(o)/k)
+	}
+}
+
+// SVD computes the reduced singular value decomposition of each matrix in t with one-sided
+// Jacobi rotations. For an m×n matrix A, with k = min(m, n), SVD returns the m×k matrix U, the k
+// singular values s, and the n×k matrix V, such that A = U*diag(s)*Vᵀ. Note that V is returned,
+// not Vᵀ.
+//
+// The singular values have dimensions [k, batch...], and are sorted in descending order. The
+// columns of U and V are orthonormal, including for singular values that are zero.
+//
+// SVD will panic if any of the error conditions from SVDSafe are met.
+func SVD(t tensors.Tensor) (tensors.Tensor, tensors.Tensor, tensors.Tensor) {
+	u, s, v, err := SVDSafe(t)
+	if err != nil {
+		panic(err)
+	}
+
+	return u, s, v
+}
+
+// SVDSafe undergoes the same process as SVD, but returns error instead of panicking. SVDSafe
+// returns RankError if t has fewer than two dimensions, and ErrNoConverge if the iteration does
+// not converge.
+func SVDSafe(t tensors.Tensor) (tensors.Tensor, tensors.Tensor, tensors.Tensor, error) {
+	b, err := batchOf(t)
+	if err != nil {
+		return tensors.Tensor{}, tensors.Tensor{}, tensors.Tensor{}, err
+	}
+
+	m, n := b.rows, b.cols
+
+	// the decomposition is always performed on a tall matrix, transposing wide ones
+	tall, short := m, n
+	if m < n {
+		tall, short = n, m
+	}
+
+	u := tensors.NewTensor(b.tensorDims(m, short))
+	s := tensors.NewTensor(append([]int{short}, b.dims...))
+	v := tensors.NewTensor(b.tensorDims(n, short))
+
+	w := make([]float64, tall*short)
+	for mat := 0; mat < b.count; mat++ {
+		a := matrix(t.Values, mat, m, n)
+		if m >= n {
+			copy(w, a)
+		} else {
+			for i := 0; i < m; i++ {
+				for j := 0; j < n; j++ {
+					w[j*m+i] = a[i*n+j]
+				}
+			}
+		}
+
+		um, vm := matrix(u.Values, mat, m, short), matrix(v.Values, mat, n, short)
+		if m < n {
+			um, vm = vm, um
+		}
+
+		sm := s.Values[mat*short : (mat+1)*short]
+		if !jacobiSVD(w, um, sm, vm, tall, short) {
+			return tensors.Tensor{}, tensors.Tensor{}, tensors.Tensor{}, ErrNoConverge
+		}
+	}
+
+	return u, s, v, nil
+}
+
+// jacobiSVD computes the singular value decomposition of the rows×cols matrix w (with rows ≥
+// cols), destroying it in the process. It returns false if the iteration did not converge.
+func jacobiSVD(w, u, s, v []float64, rows, cols int) bool {
+	for i := range v {
+		v[i] = 0
+	}
+	for i := 0; i < cols; i++ {
+		v[i*cols+i] = 1
+	}
+
+	converged := false
+	for sweep := 0; sweep < maxSweeps && !converged; sweep++ {
+		converged = true
+
+		for p := 0; p < cols; p++ {
+			for q := p + 1; q < cols; q++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < rows; i++ {
+					wp, wq := w[i*cols+p], w[i*cols+q]
+					alpha += wp * wp
+					beta += wq * wq
+					gamma += wp * wq
+				}
+
+				if math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+
+				converged = false
+				c, sn := rotation(alpha, beta, gamma)
+				rotateColumns(w, rows, cols, p, q, c, sn)
+				rotateColumns(v, cols, cols, p, q, c, sn)
+			}
+		}
+	}
+
+	if !converged {
+		return false
+	}
+
+	for j := 0; j < cols; j++ {
+		norm := 0.0
+		for i := 0; i < rows; i++ {
+			norm += w[i*cols+j] * w[i*cols+j]
+		}
+
+		s[j] = math.Sqrt(norm)
+	}
+
+	sortColumns(s, w, rows, v)
+
+	// columns of U with (numerically) zero singular values are filled in afterwards
+	tiny := float64(rows) * 1e-15 * s[0]
+	valid := make([]bool, cols)
+	for j := 0; j < cols; j++ {
+		if s[j] > tiny {
+			valid[j] = true
+			for i := 0; i < rows; i++ {
+				u[i*cols+j] = w[i*cols+j] / s[j]
+			}
+		}
+	}
+
+	completeColumns(u, valid, rows, cols)
+	return true
+}
+
+// completeColumns fills each column of u that is not valid with a unit vector orthogonal to all
+// other columns, using Gram-Schmidt on the standard basis.
+func completeColumns(u []float64, valid []bool, rows, cols int) {
+	vec := make([]float64, rows)
+	e := 0
+
+	for j := 0; j < cols; j++ {
+		for ; !valid[j] && e < rows; e++ {
+			for i := range vec {
+				vec[i] = 0
+			}
+			vec[e] = 1
+
+			for l := 0; l < cols; l++ {
+				if !valid[l] {
+					continue
+				}
+
+				dot := u[e*cols+l]
+				for i := range vec {
+					vec[i] -= dot * u[i*cols+l]
+				}
+			}
+
+			norm := 0.0
+			for _, x := range vec {
+				norm += x * x
+			}
+
+			// vectors too close to the span of the others would lose precision
+			if norm = math.Sqrt(norm); norm > 0.5 {
+				for i, x := range vec {
+					u[i*cols+j] = x / norm
+				}
+
+				valid[j] = true
+			}
+		}
+	}
+}
+
+// EighTopK returns only the k largest eigenvalues of each matrix in t, and their eigenvectors.
+// For more information, see Eigh.
+//
+// EighTopK will panic if any of the error conditions from EighTopKSafe are met.
+func EighTopK(t tensors.Tensor, k int) (tensors.Tensor, tensors.Tensor) {
+	w, v, err := EighTopKSafe(t, k)
+	if err != nil {
+		panic(err)
+	}
+
+	return w, v
+}
+
+// EighTopKSafe undergoes the same process as EighTopK, but returns error instead of panicking.
+// In addition to the errors from EighSafe, EighTopKSafe returns ErrTopK if k is not within
+// [1, n].
+func EighTopKSafe(t tensors.Tensor, k int) (tensors.Tensor, tensors.Tensor, error) {
+	w, v, err := EighSafe(t)
+	if err != nil {
+		return w, v, err
+	} else if k < 1 || k > w.Dims[0] {
+		return tensors.Tensor{}, tensors.Tensor{}, ErrTopK
+	}
+
+	return truncate(w, k), truncate(v, k), nil
+}
+
+// SVDTopK returns only the k largest singular values of each matrix in t, with their singular
+// vectors. This is the best rank-k approximation of each matrix. For more information, see SVD.
+//
+// SVDTopK will panic if any of the error conditions from SVDTopKSafe are met.
+func SVDTopK(t tensors.Tensor, k int) (tensors.Tensor, tensors.Tensor, tensors.Tensor) {
+	u, s, v, err := SVDTopKSafe(t, k)
+	if err != nil {
+		panic(err)
+	}
+
+	return u, s, v
+}
+
+// SVDTopKSafe undergoes the same process as SVDTopK, but returns error instead of panicking. In
+// addition to the errors from SVDSafe, SVDTopKSafe returns ErrTopK if k is not within
+// [1, min(m, n)].
+func SVDTopKSafe(t tensors.Tensor, k int) (tensors.Tensor, tensors.Tensor, tensors.Tensor, error) {
+	u, s, v, err := SVDSafe(t)
+	if err != nil {
+		return u, s, v, err
+	} else if k < 1 || k > s.Dims[0] {
+		return tensors.Tensor{}, tensors.Tensor{}, tensors.Tensor{}, ErrTopK
+	}
+
+	return truncate(u, k), truncate(s, k), truncate(v, k), nil
+}
+
+// truncate returns the first k values along the first dimension of t; ie. the first k columns of
+// each matrix, or the first k values of each vector.
+func truncate(t tensors.Tensor, k int) tensors.Tensor {
+	cols := t.Dims[0]
+
+	dims := make([]int, len(t.Dims))
+	copy(dims, t.Dims)
+	dims[0] = k
+
+	out := tensors.NewTensor(dims)
+	for r := 0; r*cols < len(t.Values); r++ {
+		copy(out.Values[r*k:(r+1)*k], t.Values[r*cols:r*cols+k])
+	}
+
+	return out
+}
+
+// PseudoInverse returns the Moore-Penrose pseudo-inverse of each matrix in t, computed from its
+// SVD. Singular values less than or equal to rcond times the largest singular value are treated
+// as zero; a typical value for rcond is 1e-15. The pseudo-inverse of an m×n matrix is n×m.
+//
+// PseudoInverse will panic if any of the error conditions from PseudoInverseSafe are met.
+func PseudoInverse(t tensors.Tensor, rcond float64) tensors.Tensor {
+	p, err := PseudoInverseSafe(t, rcond)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// PseudoInverseSafe undergoes the same process as PseudoInverse, but returns error instead of
+// panicking. PseudoInverseSafe returns the same errors as SVDSafe.
+func PseudoInverseSafe(t tensors.Tensor, rcond float64) (tensors.Tensor, error) {
+	u, s, v, err := SVDSafe(t)
+	if err != nil {
+		return tensors.Tensor{}, err
+	}
+
+	b, _ := batchOf(t)
+	m, n, k := b.rows, b.cols, s.Dims[0]
+	p := tensors.NewTensor(b.tensorDims(n, m))
+
+	for mat := 0; mat < b.count; mat++ {
+		um, vm := matrix(u.Values, mat, m, k), matrix(v.Values, mat, n, k)
+		sm, pm := s.Values[mat*k:(mat+1)*k], matrix(p.Values, mat, n, m)
+
+		// A⁺ = V * diag(1/s) * Uᵀ
+		for l, sv := range sm {
+			if sv <= rcond*sm[0] || sv == 0 {
+				break
+			}
+
+			for i := 0; i < n; i++ {
+				f := vm[i*k+l] / sv
+				for j := 0; j < m; j++ {
+					pm[i*m+j] += f * um[j*k+l]
+				}
+			}
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,181 @@
+package linalg
+
+import (
+	"github.com/sharnoff/tensors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// batchTranspose transposes each matrix in t
+func batchTranspose(t tensors.Tensor) tensors.Tensor {
+	rows, cols := t.Dims[1], t.Dims[0]
+	out := tensors.NewTensor(append([]int{rows, cols}, t.Dims[2:]...))
+
+	for m := 0; m*rows*cols < len(t.Values); m++ {
+		tm, om := matrix(t.Values, m, rows, cols), matrix(out.Values, m, cols, rows)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				om[j*rows+i] = tm[i*cols+j]
+			}
+		}
+	}
+
+	return out
+}
+
+// reconstruct returns U*diag(s)*Vᵀ for each matrix in the batch
+func reconstruct(u, s, v tensors.Tensor) tensors.Tensor {
+	us := tensors.NewTensor(u.Dims)
+	k := u.Dims[0]
+	for i := range us.Values {
+		us.Values[i] = u.Values[i] * s.Values[(i/(k*u.Dims[1]))*k+i%k]
+	}
+
+	return batchMatMul(us, batchTranspose(v))
+}
+
+// checkOrthonormal reports an error if the columns of any matrix in t are not orthonormal
+func checkOrthonormal(t *testing.T, name string, q tensors.Tensor) {
+	res := batchMatMul(batchTranspose(q), q)
+
+	k := q.Dims[0]
+	id := tensors.NewTensor(res.Dims)
+	for i := range id.Values {
+		if i%k == (i/k)%k {
+			id.Values[i] = 1
+		}
+	}
+
+	if !approxEqual(id, res, 1e-12) {
+		t.Errorf("%s: Columns are not orthonormal. Got %v.", name, q)
+	}
+}
+
+// requires LU
+func tEigh(t *testing.T) {
+	w, v, err := EighSafe(tensors.FromNested([][]float64{{2, 1}, {1, 2}}))
+	if handleErrors(t, "Eigh", nil, err, "") {
+		if expected := tensors.FromNested([]float64{3, 1}); !approxEqual(expected, w, 1e-12) {
+			t.Errorf("Eigh: Bad eigenvalues. Expected %v, Got %v.", expected, w)
+		}
+
+		checkOrthonormal(t, "Eigh", v)
+	}
+
+	// random symmetric matrices
+	rng := rand.New(rand.NewSource(4))
+	a := tensors.NewRandom([]int{5, 5, 2}, tensors.Uniform(-1, 1), rng)
+	a = tensors.Add(a, batchTranspose(a))
+
+	w, v, err = EighSafe(a)
+	if handleErrors(t, "Eigh", nil, err, "Random.") {
+		if res := reconstruct(v, w, v); !approxEqual(a, res, 1e-12) {
+			t.Errorf("Eigh: V*diag(w)*Vᵀ does not match A. Expected %v, Got %v.", a, res)
+		}
+
+		checkOrthonormal(t, "Eigh", v)
+		for i := 1; i < len(w.Values); i++ {
+			if i%5 != 0 && w.Values[i] > w.Values[i-1] {
+				t.Errorf("Eigh: Eigenvalues are not sorted. Got %v.", w)
+			}
+		}
+	}
+
+	_, _, err = EighSafe(tensors.NewTensor([]int{2, 3}))
+	handleErrors(t, "Eigh", NotSquareError{}, err, "Dims: [2 3].")
+}
+
+// requires LU
+func tSVD(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	table := []tensors.Tensor{
+		tensors.NewRandom([]int{5, 3}, tensors.Uniform(-1, 1), rng),
+		tensors.NewRandom([]int{3, 5}, tensors.Uniform(-1, 1), rng),
+		tensors.NewRandom([]int{4, 4, 2}, tensors.Uniform(-1, 1), rng),
+		tensors.FromNested([][]float64{{1, 2}, {2, 4}, {3, 6}}),
+		tensors.FromNested([][]float64{{1, 2, 3}, {2, 4, 6}}),
+		tensors.NewTensor([]int{2, 2}),
+	}
+
+	for _, a := range table {
+		u, s, v, err := SVDSafe(a)
+		if !handleErrors(t, "SVD", nil, err, "A: %v.", a) {
+			continue
+		}
+
+		if res := reconstruct(u, s, v); !approxEqual(a, res, 1e-12) {
+			t.Errorf("SVD: U*diag(s)*Vᵀ does not match A. Expected %v, Got %v.", a, res)
+		}
+
+		checkOrthonormal(t, "SVD", u)
+		checkOrthonormal(t, "SVD", v)
+	}
+
+	_, s, _ := SVD(table[3])
+	if expected := tensors.FromNested([]float64{math.Sqrt(70), 0}); !approxEqual(expected, s, 1e-12) {
+		t.Errorf("SVD: Bad singular values. Expected %v, Got %v.", expected, s)
+	}
+
+	_, _, _, err := SVDSafe(tensors.NewTensor([]int{3}))
+	handleErrors(t, "SVD", RankError{}, err, "Dims: [3].")
+}
+
+// requires Eigh, SVD
+func tTopK(t *testing.T) {
+	w, v, err := EighTopKSafe(tensors.FromNested([][]float64{{2, 1}, {1, 2}}), 1)
+	if handleErrors(t, "EighTopK", nil, err, "") {
+		if expected := tensors.FromNested([]float64{3}); !approxEqual(expected, w, 1e-12) {
+			t.Errorf("EighTopK: Bad eigenvalues. Expected %v, Got %v.", expected, w)
+		}
+
+		handleReturn(t, "EighTopK", []int{1, 2}, v.Dims, "")
+	}
+
+	// the rank-1 approximation of a rank-1 matrix is exact
+	a := tensors.FromNested([][][]float64{{{1, 2}, {2, 4}, {3, 6}}, {{0, 1}, {0, 2}, {0, 3}}})
+	u, s, v, err := SVDTopKSafe(a, 1)
+	if handleErrors(t, "SVDTopK", nil, err, "") {
+		handleReturn(t, "SVDTopK", []int{1, 2}, s.Dims, "")
+		if res := reconstruct(u, s, v); !approxEqual(a, res, 1e-12) {
+			t.Errorf("SVDTopK: Bad approximation. Expected %v, Got %v.", a, res)
+		}
+	}
+
+	for _, k := range []int{0, 3} {
+		_, _, err := EighTopKSafe(tensors.NewTensor([]int{2, 2}), k)
+		handleErrors(t, "EighTopK", ErrTopK, err, "K: %d.", k)
+
+		_, _, _, err = SVDTopKSafe(tensors.NewTensor([]int{2, 4}), k)
+		handleErrors(t, "SVDTopK", ErrTopK, err, "K: %d.", k)
+	}
+}
+
+// requires SVD, Inverse
+func tPseudoInverse(t *testing.T) {
+	a := tensors.FromNested([][]float64{{4, 7}, {2, 6}})
+	p, err := PseudoInverseSafe(a, 1e-15)
+	if handleErrors(t, "PseudoInverse", nil, err, "") && !approxEqual(Inverse(a), p, 1e-12) {
+		t.Errorf("PseudoInverse: Does not match Inverse. Expected %v, Got %v.", Inverse(a), p)
+	}
+
+	// the Moore-Penrose conditions, for rank-deficient and non-square matrices
+	for _, a := range []tensors.Tensor{
+		tensors.FromNested([][]float64{{1, 2}, {2, 4}, {3, 6}}),
+		tensors.FromNested([][][]float64{{{1, 0, 2}, {0, 1, 1}}, {{3, 1, 4}, {1, 5, 9}}}),
+	} {
+		p, err := PseudoInverseSafe(a, 1e-15)
+		if !handleErrors(t, "PseudoInverse", nil, err, "A: %v.", a) {
+			continue
+		}
+
+		if res := batchMatMul(batchMatMul(a, p), a); !approxEqual(a, res, 1e-12) {
+			t.Errorf("PseudoInverse: A*P*A does not match A. Expected %v, Got %v.", a, res)
+		}
+
+		if res := batchMatMul(batchMatMul(p, a), p); !approxEqual(p, res, 1e-12) {
+			t.Errorf("PseudoInverse: P*A*P does not match P. Expected %v, Got %v.", p, res)
+		}
+	}
+}
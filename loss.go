@@ -0,0 +1,366 @@
+package tensors
+
+import "math"
+
+// Reduction determines how the individual losses computed by a loss function are combined.
+type Reduction int
+
+const (
+	// ReduceMean gives the mean of the individual losses, with Dims [1]
+	ReduceMean Reduction = iota
+
+	// ReduceSum gives the sum of the individual losses, with Dims [1]
+	ReduceSum
+
+	// ReduceNone gives the individual losses, without combining them
+	ReduceNone
+)
+
+// probEpsilon is the smallest probability that is given to a logarithm by the loss functions
+// that take probabilities, so that a probability of zero does not give an infinite loss.
+const probEpsilon = 1e-12
+
+// reduce combines the individual losses according to the Reduction.
+func (r Reduction) reduce(losses Tensor) Tensor {
+	switch r {
+	case ReduceMean:
+		return Full([]int{1}, losses.Sum()/float64(losses.Size()))
+	case ReduceSum:
+		return Full([]int{1}, losses.Sum())
+	default:
+		return losses
+	}
+}
+
+// scale returns the factor that the gradient of each individual loss is multiplied by, given the
+// number of losses. With ReduceNone, the gradient is that of the sum of the losses.
+func (r Reduction) scale(n int) float64 {
+	if r == ReduceMean {
+		return 1 / float64(n)
+	}
+
+	return 1
+}
+
+// checkLoss checks the arguments shared by all of the loss functions and their gradients,
+// wrapping any error in an OpError with the name of the loss function.
+func checkLoss(op string, pred, target Tensor, r Reduction) error {
+	err := checkLayout(pred.Interpreter, target.Interpreter)
+	if r < ReduceMean || r > ReduceNone {
+		err = ErrReduction
+	} else if err == nil {
+		err = checkShapes(-1, []Tensor{pred, target})
+	}
+
+	return opError(op, err, pred.Interpreter, target.Interpreter)
+}
+
+// elementLoss is the shared implementation of the loss functions that operate on each value
+// independently. fn gives the loss for each pair of prediction and target, and op is the name of
+// the loss function, for errors.
+func elementLoss(op string, pred, target Tensor, r Reduction,
+	fn func(y, t float64) float64) (Tensor, error) {
+
+	if err := checkLoss(op, pred, target, r); err != nil {
+		return Tensor{}, err
+	}
+
+	losses := Tensor{Interpreter: pred.Interpreter, Values: make([]float64, len(pred.Values))}
+	for i := range losses.Values {
+		losses.Values[i] = fn(pred.Values[i], target.Values[i])
+	}
+
+	return r.reduce(losses), nil
+}
+
+// elementLossGrad is the shared implementation of the gradients of elementLoss. fn gives the
+// derivative of the loss for each pair of prediction and target.
+func elementLossGrad(op string, pred, target Tensor, r Reduction,
+	fn func(y, t float64) float64) (Tensor, error) {
+
+	if err := checkLoss(op, pred, target, r); err != nil {
+		return Tensor{}, err
+	}
+
+	scale := r.scale(pred.Size())
+	grad := Tensor{Interpreter: pred.Interpreter, Values: make([]float64, len(pred.Values))}
+	for i := range grad.Values {
+		grad.Values[i] = scale * fn(pred.Values[i], target.Values[i])
+	}
+
+	return grad, nil
+}
+
+// MSE returns the mean squared error between the predictions and targets: (pred - target)^2 for
+// each value, combined according to r.
+//
+// All of the loss functions and their gradients will panic with ErrReduction if r is not one of the
+// defined Reductions, or a ShapeMismatchError naming target as operand 1 if it does not have the
+// same dimensions as pred, wrapped in an OpError. The 'Safe' variants return these errors instead.
+func MSE(pred, target Tensor, r Reduction) Tensor {
+	return must(MSESafe(pred, target, r))
+}
+
+// MSESafe undergoes the same process as MSE, but returns error instead of panicking.
+func MSESafe(pred, target Tensor, r Reduction) (Tensor, error) {
+	return elementLoss("MSE", pred, target, r, func(y, t float64) float64 {
+		return (y - t) * (y - t)
+	})
+}
+
+// MSEGrad returns the gradient of MSE with respect to pred. With ReduceNone, it is the gradient of
+// the sum of the individual losses, as it is for all of the loss gradients.
+func MSEGrad(pred, target Tensor, r Reduction) Tensor {
+	return must(MSEGradSafe(pred, target, r))
+}
+
+// MSEGradSafe undergoes the same process as MSEGrad, but returns error instead of panicking.
+func MSEGradSafe(pred, target Tensor, r Reduction) (Tensor, error) {
+	return elementLossGrad("MSEGrad", pred, target, r, func(y, t float64) float64 {
+		return 2 * (y - t)
+	})
+}
+
+// Huber returns the Huber loss between the predictions and targets, which is quadratic for errors
+// smaller than delta and linear otherwise:
+//		0.5 * d^2                  if |d| ≤ delta
+//		delta * (|d| - 0.5*delta)  otherwise
+// where d = pred - target.
+func Huber(pred, target Tensor, delta float64, r Reduction) Tensor {
+	return must(HuberSafe(pred, target, delta, r))
+}
+
+// HuberSafe undergoes the same process as Huber, but returns error instead of panicking.
+func HuberSafe(pred, target Tensor, delta float64, r Reduction) (Tensor, error) {
+	return elementLoss("Huber", pred, target, r, func(y, t float64) float64 {
+		if d := math.Abs(y - t); d > delta {
+			return delta * (d - 0.5*delta)
+		}
+
+		return 0.5 * (y - t) * (y - t)
+	})
+}
+
+// HuberGrad returns the gradient of Huber with respect to pred.
+func HuberGrad(pred, target Tensor, delta float64, r Reduction) Tensor {
+	return must(HuberGradSafe(pred, target, delta, r))
+}
+
+// HuberGradSafe undergoes the same process as HuberGrad, but returns error instead of panicking.
+func HuberGradSafe(pred, target Tensor, delta float64, r Reduction) (Tensor, error) {
+	return elementLossGrad("HuberGrad", pred, target, r, func(y, t float64) float64 {
+		return math.Max(-delta, math.Min(delta, y-t))
+	})
+}
+
+// Hinge returns the hinge loss between the predictions and targets, which should be either -1 or
+// 1: max(0, 1 - pred*target).
+func Hinge(pred, target Tensor, r Reduction) Tensor {
+	return must(HingeSafe(pred, target, r))
+}
+
+// HingeSafe undergoes the same process as Hinge, but returns error instead of panicking.
+func HingeSafe(pred, target Tensor, r Reduction) (Tensor, error) {
+	return elementLoss("Hinge", pred, target, r, func(y, t float64) float64 {
+		return math.Max(0, 1-y*t)
+	})
+}
+
+// HingeGrad returns the gradient of Hinge with respect to pred. The derivative where
+// pred*target = 1 is taken to be zero.
+func HingeGrad(pred, target Tensor, r Reduction) Tensor {
+	return must(HingeGradSafe(pred, target, r))
+}
+
+// HingeGradSafe undergoes the same process as HingeGrad, but returns error instead of panicking.
+func HingeGradSafe(pred, target Tensor, r Reduction) (Tensor, error) {
+	return elementLossGrad("HingeGrad", pred, target, r, func(y, t float64) float64 {
+		if 1-y*t > 0 {
+			return -t
+		}
+
+		return 0
+	})
+}
+
+// BinaryCrossEntropy returns the binary cross-entropy between predicted probabilities and targets
+// in [0, 1]: -(target*log(pred) + (1-target)*log(1-pred)). Probabilities are clamped to
+// [1e-12, 1 - 1e-12] so that the loss is always finite.
+func BinaryCrossEntropy(pred, target Tensor, r Reduction) Tensor {
+	return must(BinaryCrossEntropySafe(pred, target, r))
+}
+
+// BinaryCrossEntropySafe undergoes the same process as BinaryCrossEntropy, but returns error
+// instead of panicking.
+func BinaryCrossEntropySafe(pred, target Tensor, r Reduction) (Tensor, error) {
+	return elementLoss("BinaryCrossEntropy", pred, target, r, func(y, t float64) float64 {
+		y = clampProb(y)
+		return -(t*math.Log(y) + (1-t)*math.Log(1-y))
+	})
+}
+
+// BinaryCrossEntropyGrad returns the gradient of BinaryCrossEntropy with respect to pred. As the
+// loss is constant where pred is clamped, the gradient there is zero.
+func BinaryCrossEntropyGrad(pred, target Tensor, r Reduction) Tensor {
+	return must(BinaryCrossEntropyGradSafe(pred, target, r))
+}
+
+// BinaryCrossEntropyGradSafe undergoes the same process as BinaryCrossEntropyGrad, but returns
+// error instead of panicking.
+func BinaryCrossEntropyGradSafe(pred, target Tensor, r Reduction) (Tensor, error) {
+	return elementLossGrad("BinaryCrossEntropyGrad", pred, target, r, func(y, t float64) float64 {
+		if y < probEpsilon || y > 1-probEpsilon {
+			return 0
+		}
+
+		return (y - t) / (y * (1 - y))
+	})
+}
+
+// clampProb restricts a probability so that neither it nor its complement are too close to zero.
+func clampProb(p float64) float64 {
+	return math.Max(probEpsilon, math.Min(1-probEpsilon, p))
+}
+
+// classLoss is the shared implementation of the cross-entropy losses, where each lane along the
+// class axis gives a single loss. fn gives the loss for a single lane.
+func classLoss(op string, pred, target Tensor, axis int, r Reduction,
+	fn func(start, stride, n int) float64) (Tensor, error) {
+
+	if err := checkLoss(op, pred, target, r); err != nil {
+		return Tensor{}, err
+	} else if err := pred.checkAxis(axis); err != nil {
+		return Tensor{}, opError(op, err, pred.Interpreter, target.Interpreter)
+	}
+
+	dims := make([]int, len(pred.Dims))
+	copy(dims, pred.Dims)
+	dims[axis] = 1
+
+	// as with LogSumExp, lanes are given in the same order as the values of the result
+	losses := NewTensor(dims)
+	lane := 0
+	pred.eachLane(axis, func(start, stride, n int) {
+		losses.Values[lane] = fn(start, stride, n)
+		lane++
+	})
+
+	return r.reduce(losses), nil
+}
+
+// CrossEntropyWithLogits returns the cross-entropy between the softmax of the logits along the
+// class axis and the target probabilities, which may be one-hot or soft labels. The loss for each
+// sample is -sum(target * LogSoftmax(logits)) along the axis, computed stably. With ReduceNone,
+// the result has the same dimensions as logits, except that Dims[axis] is 1; the mean is taken
+// over samples.
+//
+// In addition to the errors common to all loss functions, CrossEntropyWithLogits will panic with
+// an AxisError if axis is out of bounds.
+func CrossEntropyWithLogits(logits, target Tensor, axis int, r Reduction) Tensor {
+	return must(CrossEntropyWithLogitsSafe(logits, target, axis, r))
+}
+
+// CrossEntropyWithLogitsSafe undergoes the same process as CrossEntropyWithLogits, but returns
+// error instead of panicking.
+func CrossEntropyWithLogitsSafe(logits, target Tensor, axis int, r Reduction) (Tensor, error) {
+	op := "CrossEntropyWithLogits"
+	return classLoss(op, logits, target, axis, r, func(start, stride, n int) float64 {
+		lse := logSumExp(logits.Values, start, stride, n)
+
+		var loss float64
+		for a := 0; a < n; a++ {
+			i := start + a*stride
+			if target.Values[i] != 0 {
+				loss -= target.Values[i] * (logits.Values[i] - lse)
+			}
+		}
+
+		return loss
+	})
+}
+
+// CrossEntropyWithLogitsGrad returns the gradient of CrossEntropyWithLogits with respect to the
+// logits: Softmax(logits) * sum(target) - target along the axis, scaled for the reduction.
+func CrossEntropyWithLogitsGrad(logits, target Tensor, axis int, r Reduction) Tensor {
+	return must(CrossEntropyWithLogitsGradSafe(logits, target, axis, r))
+}
+
+// CrossEntropyWithLogitsGradSafe undergoes the same process as CrossEntropyWithLogitsGrad, but
+// returns error instead of panicking.
+func CrossEntropyWithLogitsGradSafe(logits, target Tensor, axis int, r Reduction) (Tensor, error) {
+	if err := checkLoss("CrossEntropyWithLogitsGrad", logits, target, r); err != nil {
+		return Tensor{}, err
+	} else if err := logits.checkAxis(axis); err != nil {
+		return Tensor{}, opError("CrossEntropyWithLogitsGrad", err, logits.Interpreter,
+			target.Interpreter)
+	}
+
+	scale := r.scale(logits.Size() / logits.Dims[axis])
+	grad := Tensor{Interpreter: logits.Interpreter, Values: make([]float64, len(logits.Values))}
+	logits.eachLane(axis, func(start, stride, n int) {
+		lse := logSumExp(logits.Values, start, stride, n)
+
+		var sum float64
+		for a := 0; a < n; a++ {
+			sum += target.Values[start+a*stride]
+		}
+
+		for a := 0; a < n; a++ {
+			i := start + a*stride
+			grad.Values[i] = scale * (math.Exp(logits.Values[i]-lse)*sum - target.Values[i])
+		}
+	})
+
+	return grad, nil
+}
+
+// CrossEntropy returns the cross-entropy between predicted and target probabilities along the
+// class axis: -sum(target * log(pred)) for each sample. Probabilities are clamped to be at least
+// 1e-12 so that the loss is always finite. It otherwise behaves as CrossEntropyWithLogits does.
+// Where the predictions come from Softmax, CrossEntropyWithLogits is more accurate.
+func CrossEntropy(pred, target Tensor, axis int, r Reduction) Tensor {
+	return must(CrossEntropySafe(pred, target, axis, r))
+}
+
+// CrossEntropySafe undergoes the same process as CrossEntropy, but returns error instead of
+// panicking.
+func CrossEntropySafe(pred, target Tensor, axis int, r Reduction) (Tensor, error) {
+	return classLoss("CrossEntropy", pred, target, axis, r, func(start, stride, n int) float64 {
+		var loss float64
+		for a := 0; a < n; a++ {
+			i := start + a*stride
+			if target.Values[i] != 0 {
+				loss -= target.Values[i] * math.Log(math.Max(probEpsilon, pred.Values[i]))
+			}
+		}
+
+		return loss
+	})
+}
+
+// CrossEntropyGrad returns the gradient of CrossEntropy with respect to pred: -target / pred,
+// scaled for the reduction. As the loss is constant where pred is clamped, the gradient there is
+// zero.
+func CrossEntropyGrad(pred, target Tensor, axis int, r Reduction) Tensor {
+	return must(CrossEntropyGradSafe(pred, target, axis, r))
+}
+
+// CrossEntropyGradSafe undergoes the same process as CrossEntropyGrad, but returns error instead
+// of panicking.
+func CrossEntropyGradSafe(pred, target Tensor, axis int, r Reduction) (Tensor, error) {
+	if err := checkLoss("CrossEntropyGrad", pred, target, r); err != nil {
+		return Tensor{}, err
+	} else if err := pred.checkAxis(axis); err != nil {
+		return Tensor{}, opError("CrossEntropyGrad", err, pred.Interpreter, target.Interpreter)
+	}
+
+	scale := r.scale(pred.Size() / pred.Dims[axis])
+	grad := Tensor{Interpreter: pred.Interpreter, Values: make([]float64, len(pred.Values))}
+	for i, t := range target.Values {
+		if t != 0 && pred.Values[i] >= probEpsilon {
+			grad.Values[i] = -scale * t / pred.Values[i]
+		}
+	}
+
+	return grad, nil
+}
@@ -0,0 +1,124 @@
+package tensors
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// requires Softmax
+func tLoss(t *testing.T) {
+	pred := FromNested([]float64{0.5, -1, 3})
+	target := FromNested([]float64{1, 1, -1})
+
+	table := []struct {
+		name   string
+		fn     func(pred, target Tensor, r Reduction) (Tensor, error)
+		values []float64
+	}{
+		{"MSE", MSESafe, []float64{0.25, 4, 16}},
+		{"Huber", func(p, t Tensor, r Reduction) (Tensor, error) { return HuberSafe(p, t, 1, r) },
+			[]float64{0.125, 1.5, 3.5}},
+		{"Hinge", HingeSafe, []float64{0.5, 2, 4}},
+	}
+
+	for _, tab := range table {
+		sum := 0.0
+		for _, v := range tab.values {
+			sum += v
+		}
+
+		expected := []Tensor{
+			FromNested([]float64{sum / 3}),
+			FromNested([]float64{sum}),
+			FromNested(tab.values),
+		}
+
+		for r, e := range expected {
+			res, err := tab.fn(pred, target, Reduction(r))
+			_ = handleErrors(t, tab.name, nil, err, "Reduction: %d.", r) &&
+				handleReturn(t, tab.name, e, res, "Reduction: %d.", r)
+		}
+	}
+
+	probs := FromNested([][]float64{{0.2, 0.3, 0.5}, {1, 0, 0}})
+	labels := FromNested([][]float64{{0, 0, 1}, {0, 1, 0}})
+
+	res, err := CrossEntropySafe(probs, labels, 0, ReduceNone)
+	expected := FromNested([][]float64{{-math.Log(0.5)}, {-math.Log(probEpsilon)}})
+	if handleErrors(t, "CrossEntropy", nil, err, "") && !approxEqual(expected, res, 1e-12) {
+		t.Errorf("CrossEntropy: Bad values. Expected %v, Got %v.", expected, res)
+	}
+
+	logits := FromNested([][]float64{{1, 2, 3}, {1000, -1000, 0}})
+	res, err = CrossEntropyWithLogitsSafe(logits, labels, 0, ReduceMean)
+	expected = FromNested([]float64{(logits.LogSumExp(0).Values[0] - 3 + 2000) / 2})
+	if handleErrors(t, "CrossEntropyWithLogits", nil, err, "") && !approxEqual(expected, res, 1e-9) {
+		t.Errorf("CrossEntropyWithLogits: Bad values. Expected %v, Got %v.", expected, res)
+	}
+
+	bce, err := BinaryCrossEntropySafe(FromNested([]float64{0.8, 0}), FromNested([]float64{1, 0}), ReduceSum)
+	expected = FromNested([]float64{-math.Log(0.8) - math.Log(1-probEpsilon)})
+	if handleErrors(t, "BinaryCrossEntropy", nil, err, "") && !approxEqual(expected, bce, 1e-12) {
+		t.Errorf("BinaryCrossEntropy: Bad values. Expected %v, Got %v.", expected, bce)
+	}
+
+	_, err = MSESafe(pred, FromNested([]float64{1, 2}), ReduceMean)
+	handleErrors(t, "MSE", ShapeMismatchError{}, err, "")
+	_, err = HingeSafe(pred, target, Reduction(5))
+	handleErrors(t, "Hinge", ErrReduction, err, "")
+	_, err = CrossEntropySafe(probs, labels, 2, ReduceMean)
+	handleErrors(t, "CrossEntropy", AxisError{}, err, "")
+	_, err = CrossEntropyWithLogitsGradSafe(logits, labels.Reshape([]int{2, 3}), 0, ReduceMean)
+	handleErrors(t, "CrossEntropyWithLogitsGrad", ShapeMismatchError{}, err, "")
+}
+
+// requires Loss, GradCheck
+func tLossGrad(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	dims := []int{4, 3}
+
+	logits := NewRandom(dims, Normal(0, 2), rng)
+	probs := logits.Softmax(0)
+	target := NewRandom(dims, Uniform(0, 1), rng).Softmax(0)
+	binary := NewRandom(dims, Uniform(0.05, 0.95), rng)
+	signs := NewRandom(dims, Uniform(-1, 1), rng).Apply(func(x float64) float64 { return math.Copysign(1, x) })
+
+	type lossFn func(pred, target Tensor, r Reduction) Tensor
+
+	table := []struct {
+		name         string
+		pred, target Tensor
+		loss, grad   lossFn
+	}{
+		{"MSE", logits, target, MSE, MSEGrad},
+		{"Huber", logits, target,
+			func(p, t Tensor, r Reduction) Tensor { return Huber(p, t, 0.7, r) },
+			func(p, t Tensor, r Reduction) Tensor { return HuberGrad(p, t, 0.7, r) }},
+		{"Hinge", logits, signs, Hinge, HingeGrad},
+		{"BinaryCrossEntropy", binary, target, BinaryCrossEntropy, BinaryCrossEntropyGrad},
+		{"CrossEntropy", probs, target,
+			func(p, t Tensor, r Reduction) Tensor { return CrossEntropy(p, t, 0, r) },
+			func(p, t Tensor, r Reduction) Tensor { return CrossEntropyGrad(p, t, 0, r) }},
+		{"CrossEntropyWithLogits", logits, target,
+			func(p, t Tensor, r Reduction) Tensor { return CrossEntropyWithLogits(p, t, 0, r) },
+			func(p, t Tensor, r Reduction) Tensor { return CrossEntropyWithLogitsGrad(p, t, 0, r) }},
+	}
+
+	for _, tab := range table {
+		for _, r := range []Reduction{ReduceMean, ReduceSum, ReduceNone} {
+			fn := func(ts []Tensor) float64 { return tab.loss(ts[0], tab.target, r).Sum() }
+			grad := tab.grad(tab.pred, tab.target, r)
+
+			ms := GradCheck(fn, []Tensor{tab.pred}, []Tensor{grad}, 1e-6, 1e-4, nil)
+			handleReturn(t, tab.name, []GradMismatch(nil), ms, "Reduction: %d.", r)
+		}
+	}
+
+	// the loss is constant where probabilities are clamped, so the gradient is zero there
+	pred, sat := FromNested([]float64{0, 1, 0.5}), FromNested([]float64{1, 0, 1})
+	handleReturn(t, "BinaryCrossEntropyGrad", FromNested([]float64{0, 0, -2}),
+		BinaryCrossEntropyGrad(pred, sat, ReduceSum), "Clamped.")
+	handleReturn(t, "CrossEntropyGrad", FromNested([]float64{0, 0, -2}),
+		CrossEntropyGrad(pred, sat, 0, ReduceSum), "Clamped.")
+}
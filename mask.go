@@ -0,0 +1,229 @@
+package tensors
+
+// Masks, as given by the comparisons here, are Tensors where every value is either 1 (true) or 0
+// (false). Where, MaskedFill and MaskedSelect accept any Tensor as a mask, treating every non-zero
+// value as true.
+//
+// All functions here that take multiple Tensors broadcast them together. Dimensions are aligned
+// starting from Dims[0], and each must either be equal or 1. Missing higher dimensions are treated
+// as 1. For example, Dims [3] and [3, 5] broadcast to [3, 5], as do [3, 1] and [1, 5].
+
+// boolValue returns 1 if b is true and 0 otherwise.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// compare is the shared implementation for the element-wise comparisons between Tensors. Errors
+// are wrapped in an OpError with the given name.
+func compare(op string, a, b Tensor, cmp func(x, y float64) bool) (Tensor, error) {
+	dims, err := broadcastDims(a.Interpreter, b.Interpreter)
+	if err != nil {
+		return Tensor{}, opError(op, err, a.Interpreter, b.Interpreter)
+	}
+
+	res := NewTensorExtended(dims)
+	broadcastEach(res.Interpreter, []Interpreter{a.Interpreter, b.Interpreter}, func(i int, idx []int) {
+		res.Values[i] = boolValue(cmp(a.Values[idx[0]], b.Values[idx[1]]))
+	})
+
+	return res, nil
+}
+
+// compareScalar is the shared implementation for the element-wise comparisons with a scalar.
+func compareScalar(t Tensor, v float64, cmp func(x, y float64) bool) Tensor {
+	res := Tensor{Interpreter: t.Interpreter, Values: make([]float64, len(t.Values))}
+	for i, x := range t.Values {
+		res.Values[i] = boolValue(cmp(x, v))
+	}
+
+	return res
+}
+
+// must panics if err is not nil, and otherwise returns t.
+func must(t Tensor, err error) Tensor {
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func eq(x, y float64) bool { return x == y }
+func ne(x, y float64) bool { return x != y }
+func lt(x, y float64) bool { return x < y }
+func le(x, y float64) bool { return x <= y }
+func gt(x, y float64) bool { return x > y }
+func ge(x, y float64) bool { return x >= y }
+
+// Equal returns a mask that is true where the values of the Tensor are equal to those of u. The
+// two are broadcast together. Equal will panic with a ShapeMismatchError if they cannot be
+// broadcast; EqualSafe returns the error instead.
+func (t Tensor) Equal(u Tensor) Tensor { return must(compare("Equal", t, u, eq)) }
+
+// EqualSafe undergoes the same process as Equal, but returns error instead of panicking.
+func (t Tensor) EqualSafe(u Tensor) (Tensor, error) { return compare("Equal", t, u, eq) }
+
+// NotEqual is the same as Equal, but is true where values are not equal.
+func (t Tensor) NotEqual(u Tensor) Tensor { return must(compare("NotEqual", t, u, ne)) }
+
+// NotEqualSafe undergoes the same process as NotEqual, but returns error instead of panicking.
+func (t Tensor) NotEqualSafe(u Tensor) (Tensor, error) { return compare("NotEqual", t, u, ne) }
+
+// Less is the same as Equal, but is true where the values of the Tensor are less than those of u.
+func (t Tensor) Less(u Tensor) Tensor { return must(compare("Less", t, u, lt)) }
+
+// LessSafe undergoes the same process as Less, but returns error instead of panicking.
+func (t Tensor) LessSafe(u Tensor) (Tensor, error) { return compare("Less", t, u, lt) }
+
+// LessEqual is the same as Equal, but is true where the values of the Tensor are less than or
+// equal to those of u.
+func (t Tensor) LessEqual(u Tensor) Tensor { return must(compare("LessEqual", t, u, le)) }
+
+// LessEqualSafe undergoes the same process as LessEqual, but returns error instead of panicking.
+func (t Tensor) LessEqualSafe(u Tensor) (Tensor, error) { return compare("LessEqual", t, u, le) }
+
+// Greater is the same as Equal, but is true where the values of the Tensor are greater than those
+// of u.
+func (t Tensor) Greater(u Tensor) Tensor { return must(compare("Greater", t, u, gt)) }
+
+// GreaterSafe undergoes the same process as Greater, but returns error instead of panicking.
+func (t Tensor) GreaterSafe(u Tensor) (Tensor, error) { return compare("Greater", t, u, gt) }
+
+// GreaterEqual is the same as Equal, but is true where the values of the Tensor are greater than
+// or equal to those of u.
+func (t Tensor) GreaterEqual(u Tensor) Tensor { return must(compare("GreaterEqual", t, u, ge)) }
+
+// GreaterEqualSafe undergoes the same process as GreaterEqual, but returns error instead of
+// panicking.
+func (t Tensor) GreaterEqualSafe(u Tensor) (Tensor, error) {
+	return compare("GreaterEqual", t, u, ge)
+}
+
+// EqualScalar returns a mask that is true where the values of the Tensor are equal to v.
+func (t Tensor) EqualScalar(v float64) Tensor { return compareScalar(t, v, eq) }
+
+// NotEqualScalar returns a mask that is true where the values of the Tensor are not equal to v.
+func (t Tensor) NotEqualScalar(v float64) Tensor { return compareScalar(t, v, ne) }
+
+// LessScalar returns a mask that is true where the values of the Tensor are less than v.
+func (t Tensor) LessScalar(v float64) Tensor { return compareScalar(t, v, lt) }
+
+// LessEqualScalar returns a mask that is true where the values of the Tensor are less than or
+// equal to v.
+func (t Tensor) LessEqualScalar(v float64) Tensor { return compareScalar(t, v, le) }
+
+// GreaterScalar returns a mask that is true where the values of the Tensor are greater than v. For
+// example, t.GreaterScalar(0) is the derivative of ReLU at t.
+func (t Tensor) GreaterScalar(v float64) Tensor { return compareScalar(t, v, gt) }
+
+// GreaterEqualScalar returns a mask that is true where the values of the Tensor are greater than
+// or equal to v.
+func (t Tensor) GreaterEqualScalar(v float64) Tensor { return compareScalar(t, v, ge) }
+
+// Where returns a new Tensor with values taken from a where cond is true (non-zero), and from b
+// where it is false. All three are broadcast together.
+//
+// Where will panic with a ShapeMismatchError if the Tensors cannot be broadcast together. cond is
+// operand 0, a is 1, and b is 2. WhereSafe returns the error instead.
+func Where(cond, a, b Tensor) Tensor {
+	return must(WhereSafe(cond, a, b))
+}
+
+// WhereSafe undergoes the same process as Where, but returns error instead of panicking.
+func WhereSafe(cond, a, b Tensor) (Tensor, error) {
+	ins := []Interpreter{cond.Interpreter, a.Interpreter, b.Interpreter}
+	dims, err := broadcastDims(ins...)
+	if err != nil {
+		return Tensor{}, opError("Where", err, cond.Interpreter, a.Interpreter, b.Interpreter)
+	}
+
+	res := NewTensorExtended(dims)
+	broadcastEach(res.Interpreter, ins, func(i int, idx []int) {
+		if cond.Values[idx[0]] != 0 {
+			res.Values[i] = a.Values[idx[1]]
+		} else {
+			res.Values[i] = b.Values[idx[2]]
+		}
+	})
+
+	return res, nil
+}
+
+// MaskedFill returns a copy of the Tensor, where every value for which mask is true (non-zero) is
+// replaced by the given value. The mask is broadcast to the dimensions of the Tensor.
+//
+// MaskedFill will panic with a ShapeMismatchError if mask cannot be broadcast to the dimensions of
+// the Tensor. MaskedFillSafe returns the error instead.
+func (t Tensor) MaskedFill(mask Tensor, value float64) Tensor {
+	return must(t.MaskedFillSafe(mask, value))
+}
+
+// MaskedFillSafe undergoes the same process as MaskedFill, but returns error instead of panicking.
+func (t Tensor) MaskedFillSafe(mask Tensor, value float64) (Tensor, error) {
+	if err := t.checkBroadcastTo(mask); err != nil {
+		return Tensor{}, opError("MaskedFill", err, t.Interpreter, mask.Interpreter)
+	}
+
+	res := Tensor{Interpreter: t.Interpreter, Values: make([]float64, len(t.Values))}
+	broadcastEach(t.Interpreter, []Interpreter{mask.Interpreter}, func(i int, idx []int) {
+		if mask.Values[idx[0]] != 0 {
+			res.Values[i] = value
+		} else {
+			res.Values[i] = t.Values[i]
+		}
+	})
+
+	return res, nil
+}
+
+// MaskedSelect returns a new one-dimensional Tensor with the values of the Tensor for which mask
+// is true (non-zero), in order of increasing index. The mask is broadcast to the dimensions of the
+// Tensor.
+//
+// MaskedSelect will panic with a ShapeMismatchError if mask cannot be broadcast to the dimensions
+// of the Tensor, or with ErrEmptySelection if no values are selected. MaskedSelectSafe returns
+// these errors instead.
+func (t Tensor) MaskedSelect(mask Tensor) Tensor {
+	return must(t.MaskedSelectSafe(mask))
+}
+
+// MaskedSelectSafe undergoes the same process as MaskedSelect, but returns error instead of
+// panicking.
+func (t Tensor) MaskedSelectSafe(mask Tensor) (Tensor, error) {
+	if err := checkLayout(t.Interpreter, mask.Interpreter); err != nil {
+		return Tensor{}, opError("MaskedSelect", err, t.Interpreter, mask.Interpreter)
+	} else if err := t.checkBroadcastTo(mask); err != nil {
+		return Tensor{}, opError("MaskedSelect", err, t.Interpreter, mask.Interpreter)
+	}
+
+	var values []float64
+	broadcastEach(t.Interpreter, []Interpreter{mask.Interpreter}, func(i int, idx []int) {
+		if mask.Values[idx[0]] != 0 {
+			values = append(values, t.Values[i])
+		}
+	})
+
+	if len(values) == 0 {
+		return Tensor{}, opError("MaskedSelect", ErrEmptySelection, t.Interpreter, mask.Interpreter)
+	}
+
+	return Tensor{Interpreter: NewInterpreter([]int{len(values)}), Values: values}, nil
+}
+
+// checkBroadcastTo returns a ShapeMismatchError (naming mask as operand 1) if mask cannot be
+// broadcast to the dimensions of the Tensor without changing them.
+func (t Tensor) checkBroadcastTo(mask Tensor) error {
+	for i, d := range mask.Dims {
+		if d == 1 {
+			continue
+		} else if i >= len(t.Dims) || d != t.Dims[i] {
+			return ShapeMismatchError{1, i, mask.Dims, t.Dims}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package tensors
+
+import (
+	"testing"
+)
+
+// requires FromNested
+func tCompare(t *testing.T) {
+	a := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := FromNested([]float64{2, 5, 3})
+	// a is [3, 2], b is [3]: b is compared with each row of a
+
+	table := []struct {
+		name string
+		fn   func(Tensor) (Tensor, error)
+		res  Tensor
+	}{
+		{"Equal", a.EqualSafe, FromNested([][]float64{{0, 0, 1}, {0, 1, 0}})},
+		{"NotEqual", a.NotEqualSafe, FromNested([][]float64{{1, 1, 0}, {1, 0, 1}})},
+		{"Less", a.LessSafe, FromNested([][]float64{{1, 1, 0}, {0, 0, 0}})},
+		{"LessEqual", a.LessEqualSafe, FromNested([][]float64{{1, 1, 1}, {0, 1, 0}})},
+		{"Greater", a.GreaterSafe, FromNested([][]float64{{0, 0, 0}, {1, 0, 1}})},
+		{"GreaterEqual", a.GreaterEqualSafe, FromNested([][]float64{{0, 0, 1}, {1, 1, 1}})},
+	}
+
+	for _, tab := range table {
+		res, err := tab.fn(b)
+
+		_ = handleErrors(t, tab.name, nil, err, "") &&
+			handleReturn(t, tab.name, tab.res, res, "")
+
+		_, err = tab.fn(FromNested([]float64{1, 2}))
+		handleErrors(t, tab.name, ShapeMismatchError{}, err, "")
+	}
+
+	handleReturn(t, "GreaterScalar", FromNested([][]float64{{0, 0, 0}, {1, 1, 1}}), a.GreaterScalar(3), "")
+	handleReturn(t, "LessEqualScalar", FromNested([][]float64{{1, 1, 1}, {0, 0, 0}}), a.LessEqualScalar(3), "")
+	handleReturn(t, "EqualScalar", FromNested([][]float64{{0, 1, 0}, {0, 0, 0}}), a.EqualScalar(2), "")
+}
+
+// requires Compare, Constructors
+func tWhere(t *testing.T) {
+	cond := FromNested([][]float64{{1}, {0}})
+	a := FromNested([]float64{1, 2, 3})
+	b := FromNested([][]float64{{-1, -2, -3}, {-4, -5, -6}})
+
+	table := []struct {
+		cond, a, b Tensor
+		res        Tensor
+		err        error
+	}{
+		{cond, a, b, FromNested([][]float64{{1, 2, 3}, {-4, -5, -6}}), nil},
+		{a.GreaterScalar(1), a, Zeros([]int{1}), FromNested([]float64{0, 2, 3}), nil},
+		{cond, a, FromNested([]float64{1, 2}), Tensor{}, ShapeMismatchError{}},
+	}
+
+	for _, tab := range table {
+		res, err := WhereSafe(tab.cond, tab.a, tab.b)
+
+		_ = handleErrors(t, "Where", tab.err, err, "") &&
+			handleReturn(t, "Where", tab.res, res, "")
+	}
+}
+
+// requires Compare, Constructors
+func tMasked(t *testing.T) {
+	tensor := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	res, err := tensor.MaskedFillSafe(tensor.GreaterScalar(4), -1)
+	_ = handleErrors(t, "MaskedFill", nil, err, "") &&
+		handleReturn(t, "MaskedFill", FromNested([][]float64{{1, 2, 3}, {4, -1, -1}}), res, "")
+
+	res, err = tensor.MaskedFillSafe(FromNested([][]float64{{0}, {1}}), 0)
+	_ = handleErrors(t, "MaskedFill", nil, err, "") &&
+		handleReturn(t, "MaskedFill", FromNested([][]float64{{1, 2, 3}, {0, 0, 0}}), res, "")
+
+	_, err = tensor.MaskedFillSafe(Ones([]int{3, 2, 2}), 0)
+	handleErrors(t, "MaskedFill", ShapeMismatchError{}, err, "")
+
+	res, err = tensor.MaskedSelectSafe(FromNested([]float64{1, 0, 1}))
+	_ = handleErrors(t, "MaskedSelect", nil, err, "") &&
+		handleReturn(t, "MaskedSelect", FromNested([]float64{1, 3, 4, 6}), res, "")
+
+	_, err = tensor.MaskedSelectSafe(Zeros(tensor.Dims))
+	handleErrors(t, "MaskedSelect", ErrEmptySelection, err, "")
+
+	_, err = tensor.MaskedSelectSafe(Ones([]int{2}))
+	handleErrors(t, "MaskedSelect", ShapeMismatchError{}, err, "")
+}
@@ -0,0 +1,167 @@
+package tensors
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Pool recycles the Values of Tensors, to reduce allocation (and garbage collection) in loops that
+// repeatedly create temporary Tensors of similar sizes. Not to be confused with MaxPool and
+// AvgPool.
+//
+// Slices are bucketed by capacity, in powers of two, so a recycled slice may be reused for any
+// Tensor that fits within it. A Pool is safe for concurrent use, and its zero value is ready to
+// use.
+type Pool struct {
+	mux sync.Mutex
+
+	// buckets[b] stores slices with capacity of at least 2^b
+	buckets [bits.UintSize][][]float64
+}
+
+// NewPool returns a new, empty Pool.
+func NewPool() *Pool {
+	return &Pool{}
+}
+
+// Get returns a Tensor with the given dimensions and all values zero, reusing a slice from the
+// Pool if one is available. Get will panic if any of the error conditions from NewInterpreterSafe
+// are met. GetSafe returns the error instead.
+func (p *Pool) Get(dims []int) Tensor {
+	t, err := p.GetSafe(dims)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// GetSafe undergoes the same process as Get, but returns error instead of panicking.
+func (p *Pool) GetSafe(dims []int) (Tensor, error) {
+	in, err := NewInterpreterSafe(dims)
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	return Tensor{Interpreter: in, Values: p.get(in.Size())}, nil
+}
+
+// get returns a zero'd slice with the given length
+func (p *Pool) get(size int) []float64 {
+	// the smallest bucket whose slices are all large enough
+	b := bits.Len(uint(size - 1))
+
+	p.mux.Lock()
+	if n := len(p.buckets[b]); n != 0 {
+		values := p.buckets[b][n-1][:size]
+		p.buckets[b][n-1] = nil
+		p.buckets[b] = p.buckets[b][:n-1]
+		p.mux.Unlock()
+
+		for i := range values {
+			values[i] = 0
+		}
+
+		return values
+	}
+	p.mux.Unlock()
+
+	// allocate the full capacity of the bucket, so that the slice can be reused for anything in it
+	return make([]float64, size, 1<<uint(b))
+}
+
+// Put returns the Values of t to the Pool, to be reused by later calls to Get. After calling Put,
+// neither t nor any Tensor that it was assigned to may be used. If the Values of t are shared with
+// other Tensors through Share, Put only releases the share held by t; the Values are returned to
+// the Pool by whichever Tensor is the last to be given to Put.
+//
+// Tensors given to Put do not need to have come from the Pool.
+func (p *Pool) Put(t Tensor) {
+	if s := t.shared; s != nil {
+		s.mux.Lock()
+		last := s.refs <= 1
+		if !last {
+			s.refs--
+		}
+		s.mux.Unlock()
+
+		// the other Tensors are still using the Values
+		if !last {
+			return
+		}
+	}
+
+	p.put(t.Values)
+}
+
+func (p *Pool) put(values []float64) {
+	if cap(values) == 0 {
+		return
+	}
+
+	// the largest bucket that the slice can satisfy
+	b := bits.Len(uint(cap(values))) - 1
+
+	p.mux.Lock()
+	p.buckets[b] = append(p.buckets[b], values[:0])
+	p.mux.Unlock()
+}
+
+// Arena hands out Tensors from a Pool, and returns all of them at once with Release -- for
+// example, at the end of each training step. An Arena is safe for concurrent use.
+type Arena struct {
+	pool *Pool
+
+	mux  sync.Mutex
+	used [][]float64
+}
+
+// NewArena returns a new Arena that draws from the given Pool. If pool is nil, the Arena creates
+// its own.
+func NewArena(pool *Pool) *Arena {
+	if pool == nil {
+		pool = NewPool()
+	}
+
+	return &Arena{pool: pool}
+}
+
+// Get returns a Tensor with the given dimensions and all values zero, which will be returned to
+// the Pool on the next call to Release. Get will panic if any of the error conditions from
+// NewInterpreterSafe are met. GetSafe returns the error instead.
+func (a *Arena) Get(dims []int) Tensor {
+	t, err := a.GetSafe(dims)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// GetSafe undergoes the same process as Get, but returns error instead of panicking.
+func (a *Arena) GetSafe(dims []int) (Tensor, error) {
+	t, err := a.pool.GetSafe(dims)
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	a.mux.Lock()
+	a.used = append(a.used, t.Values)
+	a.mux.Unlock()
+
+	return t, nil
+}
+
+// Release returns every Tensor given out by the Arena since the last call to Release to its Pool.
+// None of those Tensors (or any others sharing their Values) may be used afterwards. The Arena
+// itself can continue to be used.
+func (a *Arena) Release() {
+	a.mux.Lock()
+	used := a.used
+	a.used = nil
+	a.mux.Unlock()
+
+	for _, values := range used {
+		a.pool.put(values)
+	}
+}
@@ -0,0 +1,98 @@
+package tensors
+
+import (
+	"sync"
+	"testing"
+)
+
+// requires NewInterpreter
+func tMemoryPool(t *testing.T) {
+	p := NewPool()
+
+	a := p.Get([]int{3, 5})
+	handleReturn(t, "Pool.Get", NewTensor([]int{3, 5}), a, "")
+	handleReturn(t, "Pool.Get", 16, cap(a.Values), "Capacity.")
+
+	// a recycled slice is reused, and cleared, for anything that fits in its bucket
+	a.Values[0] = 1
+	p.Put(a)
+
+	b := p.Get([]int{2, 6})
+	if &b.Values[0] != &a.Values[0] {
+		t.Errorf("Pool.Get: Expected recycled slice to be reused.")
+	}
+	handleReturn(t, "Pool.Get", NewTensor([]int{2, 6}), b, "Recycled.")
+
+	// ... but not for anything larger
+	p.Put(b)
+	if c := p.Get([]int{17}); &c.Values[0] == &a.Values[0] {
+		t.Errorf("Pool.Get: Recycled slice was reused for a larger Tensor.")
+	}
+
+	// shared Values are only recycled once every Tensor sharing them has been given back
+	d := p.Get([]int{64})
+	e := d.Share()
+	p.Put(d)
+	if f := p.Get([]int{64}); &f.Values[0] == &e.Values[0] {
+		t.Errorf("Pool.Put: Shared slice was reused while still in use.")
+	}
+	p.Put(e)
+	if f := p.Get([]int{64}); &f.Values[0] != &e.Values[0] {
+		t.Errorf("Pool.Put: Expected slice to be reused once no longer shared.")
+	}
+
+	// slices not from the Pool are placed in the largest bucket they can satisfy
+	p.Put(NewTensor([]int{20}))
+	if c := p.Get([]int{17}); cap(c.Values) != 32 {
+		t.Errorf("Pool.Get: Slice with capacity 20 was reused for size 17.")
+	}
+	if c := p.Get([]int{16}); cap(c.Values) != 20 {
+		t.Errorf("Pool.Get: Expected slice with capacity 20 to be reused for size 16.")
+	}
+
+	_, err := p.GetSafe([]int{2, 0})
+	handleErrors(t, "Pool.Get", DimsValueError{}, err, "Dims: [2 0].")
+
+	// concurrent use, checked with -race
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				x := p.Get([]int{i + j + 1})
+				x.Values[0] = 1
+				p.Put(x)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// requires Pool
+func tArena(t *testing.T) {
+	p := NewPool()
+	a := NewArena(p)
+
+	x, y := a.Get([]int{4}), a.Get([]int{2, 2, 2})
+	x.Values[0], y.Values[0] = 1, 1
+	a.Release()
+
+	// both slices were returned to the pool
+	for _, v := range []Tensor{x, y} {
+		if z := p.Get(v.Dims); &z.Values[0] != &v.Values[0] {
+			t.Errorf("Arena.Release: Expected slice with dims %v to be returned to the Pool.", v.Dims)
+		} else if z.Values[0] != 0 {
+			t.Errorf("Arena.Release: Reused slice was not cleared.")
+		}
+	}
+
+	// a released Arena can be used again, and may create its own pool
+	a = NewArena(nil)
+	handleReturn(t, "Arena.Get", NewTensor([]int{3}), a.Get([]int{3}), "")
+	a.Release()
+	a.Release()
+
+	_, err := a.GetSafe(nil)
+	handleErrors(t, "Arena.Get", ErrZeroDims, err, "Dims: nil.")
+}
@@ -0,0 +1,224 @@
+package tensors
+
+import "math"
+
+// NormStats stores the statistics computed by LayerNorm and BatchNorm. They are needed to compute
+// gradients with NormGrad, and the Mean and Var from BatchNorm may be used to update running
+// statistics for BatchNormInference.
+type NormStats struct {
+	// Mean and Var are the mean and (biased) variance of the normalized values. They have the
+	// same dimensions as the input, except that every normalized axis has size 1.
+	Mean Tensor
+	Var  Tensor
+
+	// Eps is the value added to Var before taking its square root
+	Eps float64
+}
+
+// reducedDims checks the axes given, and returns the dimensions of the Tensor with each of them
+// set to 1.
+func (t Tensor) reducedDims(axes []int) ([]int, error) {
+	if len(axes) == 0 {
+		return nil, ErrZeroDims
+	}
+
+	for i, a := range axes {
+		if err := t.checkAxis(a); err != nil {
+			return nil, err
+		}
+
+		for _, b := range axes[:i] {
+			if a == b {
+				return nil, ErrDuplicateAxis
+			}
+		}
+	}
+
+	dims := make([]int, len(t.Dims))
+	copy(dims, t.Dims)
+	for _, a := range axes {
+		dims[a] = 1
+	}
+
+	return dims, nil
+}
+
+// normalize is the shared implementation of LayerNorm and BatchNorm.
+func (t Tensor) normalize(axes []int, eps float64) (Tensor, NormStats, error) {
+	if err := checkLayout(t.Interpreter); err != nil {
+		return Tensor{}, NormStats{}, err
+	}
+
+	dims, err := t.reducedDims(axes)
+	if err != nil {
+		return Tensor{}, NormStats{}, err
+	}
+
+	stats := NormStats{NewTensor(dims), NewTensor(dims), eps}
+	count := float64(t.Size() / stats.Mean.Size())
+	reduced := []Interpreter{stats.Mean.Interpreter}
+
+	broadcastEach(t.Interpreter, reduced, func(i int, idx []int) {
+		stats.Mean.Values[idx[0]] += t.Values[i] / count
+	})
+
+	broadcastEach(t.Interpreter, reduced, func(i int, idx []int) {
+		d := t.Values[i] - stats.Mean.Values[idx[0]]
+		stats.Var.Values[idx[0]] += d * d / count
+	})
+
+	res := Tensor{Interpreter: t.Interpreter, Values: make([]float64, len(t.Values))}
+	broadcastEach(t.Interpreter, reduced, func(i int, idx []int) {
+		res.Values[i] = (t.Values[i] - stats.Mean.Values[idx[0]]) / math.Sqrt(stats.Var.Values[idx[0]]+eps)
+	})
+
+	return res, stats, nil
+}
+
+// LayerNorm normalizes the Tensor over the given axes, so that the values along them have mean 0
+// and variance 1. eps is added to the variance for numerical stability. Any learned scale and
+// shift can be applied afterwards with Mul and Add, which broadcast.
+//
+// For a Tensor with Dims [features, batch], LayerNorm([]int{0}, eps) normalizes each sample.
+//
+// LayerNorm will panic with ErrZeroDims if no axes are given, an AxisError if any of the axes are
+// out of bounds, or ErrDuplicateAxis if any are repeated. LayerNormSafe returns these errors
+// instead.
+func (t Tensor) LayerNorm(axes []int, eps float64) (Tensor, NormStats) {
+	res, stats, err := t.LayerNormSafe(axes, eps)
+	if err != nil {
+		panic(err)
+	}
+
+	return res, stats
+}
+
+// LayerNormSafe undergoes the same process as LayerNorm, but returns error instead of panicking.
+func (t Tensor) LayerNormSafe(axes []int, eps float64) (Tensor, NormStats, error) {
+	res, stats, err := t.normalize(axes, eps)
+	return res, stats, opError("LayerNorm", err, t.Interpreter)
+}
+
+// BatchNorm normalizes the Tensor over every axis except the given channel axis, so that the
+// values for each channel have mean 0 and variance 1. eps is added to the variance for numerical
+// stability.
+//
+// For a Tensor with Dims [features, batch], BatchNorm(0, eps) normalizes each feature over the
+// batch. For the output of Conv, with Dims [spatial..., channels, batch], the channel axis is
+// len(Dims) - 2.
+//
+// BatchNorm will panic with an AxisError if axis is out of bounds, or ErrZeroDims if the Tensor
+// has no other axes. BatchNormSafe returns these errors instead.
+func (t Tensor) BatchNorm(axis int, eps float64) (Tensor, NormStats) {
+	res, stats, err := t.BatchNormSafe(axis, eps)
+	if err != nil {
+		panic(err)
+	}
+
+	return res, stats
+}
+
+// BatchNormSafe undergoes the same process as BatchNorm, but returns error instead of panicking.
+func (t Tensor) BatchNormSafe(axis int, eps float64) (Tensor, NormStats, error) {
+	if err := t.checkAxis(axis); err != nil {
+		return Tensor{}, NormStats{}, opError("BatchNorm", err, t.Interpreter)
+	}
+
+	var axes []int
+	for a := range t.Dims {
+		if a != axis {
+			axes = append(axes, a)
+		}
+	}
+
+	res, stats, err := t.normalize(axes, eps)
+	return res, stats, opError("BatchNorm", err, t.Interpreter)
+}
+
+// BatchNormInference normalizes the Tensor with the given (running) mean and variance, instead of
+// those of the Tensor itself, as is done once training is finished. mean and variance are broadcast
+// to the dimensions of the Tensor.
+//
+// BatchNormInference will panic with a ShapeMismatchError (naming mean as operand 1 and variance as
+// operand 2) if they cannot be broadcast to the dimensions of the Tensor. BatchNormInferenceSafe
+// returns the error instead.
+func (t Tensor) BatchNormInference(mean, variance Tensor, eps float64) Tensor {
+	return must(t.BatchNormInferenceSafe(mean, variance, eps))
+}
+
+// BatchNormInferenceSafe undergoes the same process as BatchNormInference, but returns error
+// instead of panicking.
+func (t Tensor) BatchNormInferenceSafe(mean, variance Tensor, eps float64) (Tensor, error) {
+	if err := checkLayout(t.Interpreter, mean.Interpreter, variance.Interpreter); err != nil {
+		return Tensor{}, opError("BatchNormInference", err, t.Interpreter, mean.Interpreter,
+			variance.Interpreter)
+	} else if err := t.checkBroadcastTo(mean); err != nil {
+		return Tensor{}, opError("BatchNormInference", err, t.Interpreter, mean.Interpreter,
+			variance.Interpreter)
+	} else if err := t.checkBroadcastTo(variance); err != nil {
+		e := err.(ShapeMismatchError)
+		e.operand = 2
+		return Tensor{}, opError("BatchNormInference", e, t.Interpreter, mean.Interpreter,
+			variance.Interpreter)
+	}
+
+	res := Tensor{Interpreter: t.Interpreter, Values: make([]float64, len(t.Values))}
+	broadcastEach(t.Interpreter, []Interpreter{mean.Interpreter, variance.Interpreter}, func(i int, idx []int) {
+		res.Values[i] = (t.Values[i] - mean.Values[idx[0]]) / math.Sqrt(variance.Values[idx[1]]+eps)
+	})
+
+	return res, nil
+}
+
+// NormGrad returns the gradient with respect to the input of LayerNorm or BatchNorm, given their
+// output, the gradient with respect to their output, and the statistics that they returned. Over
+// each normalized group of values, this is:
+//		(g - mean(g) - y * mean(g * y)) / sqrt(var + eps)
+//
+// NormGrad will panic with a ShapeMismatchError if out and gradOut do not have the same
+// dimensions (naming gradOut as operand 1), if the statistics cannot be broadcast to them (naming
+// stats.Mean as operand 2), or if stats.Var does not have the same dimensions as stats.Mean
+// (naming stats.Var as operand 3). NormGradSafe returns the error instead.
+func NormGrad(out, gradOut Tensor, stats NormStats) Tensor {
+	return must(NormGradSafe(out, gradOut, stats))
+}
+
+// NormGradSafe undergoes the same process as NormGrad, but returns error instead of panicking.
+func NormGradSafe(out, gradOut Tensor, stats NormStats) (Tensor, error) {
+	if err := checkLayout(out.Interpreter, gradOut.Interpreter, stats.Mean.Interpreter,
+		stats.Var.Interpreter); err != nil {
+		return Tensor{}, opError("NormGrad", err, out.Interpreter, gradOut.Interpreter,
+			stats.Mean.Interpreter)
+	} else if !Equals(out.Interpreter, gradOut.Interpreter) {
+		return Tensor{}, opError("NormGrad", ShapeMismatchError{1, -1, gradOut.Dims, out.Dims},
+			out.Interpreter, gradOut.Interpreter, stats.Mean.Interpreter)
+	} else if err := out.checkBroadcastTo(stats.Mean); err != nil {
+		e := err.(ShapeMismatchError)
+		e.operand = 2
+		return Tensor{}, opError("NormGrad", e, out.Interpreter, gradOut.Interpreter,
+			stats.Mean.Interpreter)
+	} else if !Equals(stats.Mean.Interpreter, stats.Var.Interpreter) {
+		err := ShapeMismatchError{3, -1, stats.Var.Dims, stats.Mean.Dims}
+		return Tensor{}, opError("NormGrad", err, out.Interpreter, gradOut.Interpreter,
+			stats.Mean.Interpreter, stats.Var.Interpreter)
+	}
+
+	count := float64(out.Size() / stats.Mean.Size())
+	reduced := []Interpreter{stats.Mean.Interpreter}
+
+	meanG := NewTensor(stats.Mean.Dims)
+	meanGY := NewTensor(stats.Mean.Dims)
+	broadcastEach(out.Interpreter, reduced, func(i int, idx []int) {
+		meanG.Values[idx[0]] += gradOut.Values[i] / count
+		meanGY.Values[idx[0]] += gradOut.Values[i] * out.Values[i] / count
+	})
+
+	res := Tensor{Interpreter: out.Interpreter, Values: make([]float64, len(out.Values))}
+	broadcastEach(out.Interpreter, reduced, func(i int, idx []int) {
+		j := idx[0]
+		g := gradOut.Values[i] - meanG.Values[j] - out.Values[i]*meanGY.Values[j]
+		res.Values[i] = g / math.Sqrt(stats.Var.Values[j]+stats.Eps)
+	})
+
+	return res, nil
+}
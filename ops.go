@@ -0,0 +1,210 @@
+package tensors
+
+// elementwise is the shared implementation for the binary element-wise operations, broadcasting a
+// and b together. Errors are wrapped in an OpError with the given name.
+func elementwise(op string, a, b Tensor, fn func(x, y float64) float64) (Tensor, error) {
+	dims, err := broadcastDims(a.Interpreter, b.Interpreter)
+	if err != nil {
+		return Tensor{}, opError(op, err, a.Interpreter, b.Interpreter)
+	}
+
+	res := NewTensorExtended(dims)
+	broadcastEach(res.Interpreter, []Interpreter{a.Interpreter, b.Interpreter}, func(i int, idx []int) {
+		res.Values[i] = fn(a.Values[idx[0]], b.Values[idx[1]])
+	})
+
+	return res, nil
+}
+
+func add(x, y float64) float64 { return x + y }
+func sub(x, y float64) float64 { return x - y }
+func mul(x, y float64) float64 { return x * y }
+func div(x, y float64) float64 { return x / y }
+
+// Add returns the element-wise sum of a and b, broadcasting them together. Broadcasting is
+// described in the documentation for Where. Add will panic with a ShapeMismatchError, wrapped in an
+// OpError, if they cannot be broadcast; AddSafe returns the error instead.
+func Add(a, b Tensor) Tensor { return must(elementwise("Add", a, b, add)) }
+
+// AddSafe undergoes the same process as Add, but returns error instead of panicking.
+func AddSafe(a, b Tensor) (Tensor, error) { return elementwise("Add", a, b, add) }
+
+// Sub returns the element-wise difference a - b, broadcasting them together. It otherwise behaves
+// as Add does.
+func Sub(a, b Tensor) Tensor { return must(elementwise("Sub", a, b, sub)) }
+
+// SubSafe undergoes the same process as Sub, but returns error instead of panicking.
+func SubSafe(a, b Tensor) (Tensor, error) { return elementwise("Sub", a, b, sub) }
+
+// Mul returns the element-wise product of a and b, broadcasting them together. It otherwise
+// behaves as Add does.
+func Mul(a, b Tensor) Tensor { return must(elementwise("Mul", a, b, mul)) }
+
+// MulSafe undergoes the same process as Mul, but returns error instead of panicking.
+func MulSafe(a, b Tensor) (Tensor, error) { return elementwise("Mul", a, b, mul) }
+
+// Div returns the element-wise quotient a / b, broadcasting them together. It otherwise behaves as
+// Add does.
+func Div(a, b Tensor) Tensor { return must(elementwise("Div", a, b, div)) }
+
+// DivSafe undergoes the same process as Div, but returns error instead of panicking.
+func DivSafe(a, b Tensor) (Tensor, error) { return elementwise("Div", a, b, div) }
+
+// Apply returns a new Tensor with the same dimensions, where each value is the result of fn on
+// the corresponding value of t.
+func (t Tensor) Apply(fn func(float64) float64) Tensor {
+	res := Tensor{Interpreter: t.Interpreter, Values: make([]float64, len(t.Values))}
+	for i, v := range t.Values {
+		res.Values[i] = fn(v)
+	}
+
+	return res
+}
+
+// Scale returns a new Tensor with every value of t multiplied by c.
+func (t Tensor) Scale(c float64) Tensor {
+	return t.Apply(func(v float64) float64 { return v * c })
+}
+
+// Sum returns the sum of every value in the Tensor.
+func (t Tensor) Sum() float64 {
+	var sum float64
+	for _, v := range t.Values {
+		sum += v
+	}
+
+	return sum
+}
+
+// SumAxis returns a new Tensor with the values of t summed along the given axis. The result has the
+// same dimensions as t, except that Dims[axis] is 1.
+//
+// SumAxis will panic with an AxisError if axis is out of bounds. SumAxisSafe returns the error
+// instead.
+func (t Tensor) SumAxis(axis int) Tensor {
+	return must(t.SumAxisSafe(axis))
+}
+
+// SumAxisSafe undergoes the same process as SumAxis, but returns error instead of panicking.
+func (t Tensor) SumAxisSafe(axis int) (Tensor, error) {
+	if err := t.checkAxis(axis); err != nil {
+		return Tensor{}, opError("SumAxis", err, t.Interpreter)
+	}
+
+	dims := make([]int, len(t.Dims))
+	copy(dims, t.Dims)
+	dims[axis] = 1
+
+	res := NewTensorExtended(dims)
+	broadcastEach(t.Interpreter, []Interpreter{res.Interpreter}, func(i int, idx []int) {
+		res.Values[idx[0]] += t.Values[i]
+	})
+
+	return res, nil
+}
+
+// Reshape returns a Tensor with the same values as t, but with the given dimensions. The values
+// are not copied, so changes to either Tensor will be visible in both, unless t is shared; see
+// Share. Reshape does not make a copy of dims.
+//
+// Reshape will panic with any error from NewInterpreterSafe, or a LengthMismatchError if the size
+// of the new dimensions is not equal to that of t. ReshapeSafe returns these errors instead.
+func (t Tensor) Reshape(dims []int) Tensor {
+	return must(t.ReshapeSafe(dims))
+}
+
+// ReshapeSafe undergoes the same process as Reshape, but returns error instead of panicking.
+func (t Tensor) ReshapeSafe(dims []int) (Tensor, error) {
+	in, err := NewInterpreterSafe(dims)
+	if err != nil {
+		return Tensor{}, opError("Reshape", err, t.Interpreter)
+	} else if err := checkLayout(t.Interpreter); err != nil {
+		return Tensor{}, opError("Reshape", err, t.Interpreter)
+	} else if in.Size() != t.Size() {
+		return Tensor{}, opError("Reshape",
+			LengthMismatchError{"reshape size", in.Size(), t.Size()}, t.Interpreter)
+	}
+
+	// a reshaped shared Tensor is one more user of the Values, as if returned by Share
+	res := t
+	if t.shared != nil {
+		res = t.Share()
+	}
+	res.Interpreter = in
+	return res, nil
+}
+
+// MatMul returns the matrix product of a and b. Two-dimensional Tensors are treated as matrices
+// with Dims [columns, rows], matching the layout given by FromNested and Format. That is, a has
+// Dims [k, m] (m rows of k values), b has Dims [n, k], and the result has Dims [n, m].
+//
+// MatMul will panic with a LengthMismatchError if a or b are not two-dimensional, or a
+// ShapeMismatchError (naming b as operand 1 and axis 1) if the number of columns of a is not equal
+// to the number of rows of b. Both are wrapped in an OpError. MatMulSafe returns these errors
+// instead.
+func MatMul(a, b Tensor) Tensor {
+	return must(MatMulSafe(a, b))
+}
+
+// MatMulSafe undergoes the same process as MatMul, but returns error instead of panicking.
+func MatMulSafe(a, b Tensor) (Tensor, error) {
+	var err error
+	if len(a.Dims) != 2 {
+		err = LengthMismatchError{"operand 0 dims", len(a.Dims), 2}
+	} else if len(b.Dims) != 2 {
+		err = LengthMismatchError{"operand 1 dims", len(b.Dims), 2}
+	} else if a.Dims[0] != b.Dims[1] {
+		err = ShapeMismatchError{1, 1, b.Dims, []int{b.Dims[0], a.Dims[0]}}
+	} else {
+		err = checkLayout(a.Interpreter, b.Interpreter)
+	}
+
+	if err != nil {
+		return Tensor{}, opError("MatMul", err, a.Interpreter, b.Interpreter)
+	}
+
+	k, m, n := a.Dims[0], a.Dims[1], b.Dims[0]
+	res := NewTensor([]int{n, m})
+
+	for i := 0; i < m; i++ {
+		row := res.Values[n*i : n*(i+1)]
+		for l, x := range a.Values[k*i : k*(i+1)] {
+			if x == 0 {
+				continue
+			}
+
+			for j, y := range b.Values[n*l : n*(l+1)] {
+				row[j] += x * y
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// Transpose returns a new two-dimensional Tensor with the rows and columns of t swapped. Transpose
+// will panic with a LengthMismatchError if t is not two-dimensional; TransposeSafe returns the
+// error instead.
+func (t Tensor) Transpose() Tensor {
+	return must(t.TransposeSafe())
+}
+
+// TransposeSafe undergoes the same process as Transpose, but returns error instead of panicking.
+func (t Tensor) TransposeSafe() (Tensor, error) {
+	if err := checkLayout(t.Interpreter); err != nil {
+		return Tensor{}, opError("Transpose", err, t.Interpreter)
+	} else if len(t.Dims) != 2 {
+		return Tensor{}, opError("Transpose", LengthMismatchError{"dims", len(t.Dims), 2},
+			t.Interpreter)
+	}
+
+	cols, rows := t.Dims[0], t.Dims[1]
+	res := NewTensor([]int{rows, cols})
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			res.Values[i+rows*j] = t.Values[j+cols*i]
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,113 @@
+package tensors
+
+import (
+	"testing"
+)
+
+// requires FromNested
+func tArithmetic(t *testing.T) {
+	a := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := FromNested([]float64{1, 2, 4})
+
+	table := []struct {
+		name string
+		fn   func(a, b Tensor) (Tensor, error)
+		res  Tensor
+	}{
+		{"Add", AddSafe, FromNested([][]float64{{2, 4, 7}, {5, 7, 10}})},
+		{"Sub", SubSafe, FromNested([][]float64{{0, 0, -1}, {3, 3, 2}})},
+		{"Mul", MulSafe, FromNested([][]float64{{1, 4, 12}, {4, 10, 24}})},
+		{"Div", DivSafe, FromNested([][]float64{{1, 1, 0.75}, {4, 2.5, 1.5}})},
+	}
+
+	for _, tab := range table {
+		res, err := tab.fn(a, b)
+		_ = handleErrors(t, tab.name, nil, err, "") &&
+			handleReturn(t, tab.name, tab.res, res, "")
+
+		_, err = tab.fn(a, FromNested([]float64{1, 2}))
+		handleErrors(t, tab.name, ShapeMismatchError{}, err, "")
+	}
+
+	handleReturn(t, "Scale", FromNested([]float64{-2, -4, -8}), b.Scale(-2), "")
+	handleReturn(t, "Sum", 21.0, a.Sum(), "")
+
+	sum, err := a.SumAxisSafe(0)
+	_ = handleErrors(t, "SumAxis", nil, err, "") &&
+		handleReturn(t, "SumAxis", FromNested([][]float64{{6}, {15}}), sum, "")
+	sum, err = a.SumAxisSafe(1)
+	_ = handleErrors(t, "SumAxis", nil, err, "") &&
+		handleReturn(t, "SumAxis", FromNested([][]float64{{5, 7, 9}}), sum, "")
+	_, err = a.SumAxisSafe(2)
+	handleErrors(t, "SumAxis", AxisError{}, err, "")
+
+	// rank-0 and empty Tensors, from NewTensorExtended
+	res, err := AddSafe(Scalar(3), Scalar(2))
+	_ = handleErrors(t, "Add", nil, err, "Rank 0.") &&
+		handleReturn(t, "Add", Scalar(5), res, "Rank 0.")
+	res, err = MulSafe(Scalar(2), b)
+	_ = handleErrors(t, "Mul", nil, err, "Rank 0.") &&
+		handleReturn(t, "Mul", FromNested([]float64{2, 4, 8}), res, "Rank 0.")
+
+	empty := NewTensorExtended([]int{0, 3})
+	res, err = MulSafe(empty, FromNested([]float64{2}))
+	_ = handleErrors(t, "Mul", nil, err, "Empty.") &&
+		handleReturn(t, "Mul", empty, res, "Empty.")
+	_, err = MulSafe(empty, FromNested([]float64{1, 2}))
+	handleErrors(t, "Mul", ShapeMismatchError{}, err, "Empty.")
+
+	sum, err = empty.SumAxisSafe(1)
+	_ = handleErrors(t, "SumAxis", nil, err, "Empty.") &&
+		handleReturn(t, "SumAxis", NewTensorExtended([]int{0, 1}), sum, "Empty.")
+	sum, err = empty.SumAxisSafe(0)
+	_ = handleErrors(t, "SumAxis", nil, err, "Empty.") &&
+		handleReturn(t, "SumAxis", NewTensor([]int{1, 3}), sum, "Empty.")
+}
+
+// requires FromNested
+func tReshape(t *testing.T) {
+	a := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	res, err := a.ReshapeSafe([]int{2, 3})
+	_ = handleErrors(t, "Reshape", nil, err, "") &&
+		handleReturn(t, "Reshape", FromNested([][]float64{{1, 2}, {3, 4}, {5, 6}}), res, "")
+
+	_, err = a.ReshapeSafe([]int{5})
+	handleErrors(t, "Reshape", LengthMismatchError{}, err, "")
+	_, err = a.ReshapeSafe(nil)
+	handleErrors(t, "Reshape", ErrZeroDims, err, "")
+
+	tr, err := a.TransposeSafe()
+	_ = handleErrors(t, "Transpose", nil, err, "") &&
+		handleReturn(t, "Transpose", FromNested([][]float64{{1, 4}, {2, 5}, {3, 6}}), tr, "")
+	_, err = FromNested([]float64{1}).TransposeSafe()
+	handleErrors(t, "Transpose", LengthMismatchError{}, err, "")
+}
+
+// requires FromNested, Constructors
+func tMatMul(t *testing.T) {
+	a := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := FromNested([][]float64{{1, 0}, {0, 1}, {2, -1}})
+
+	table := []struct {
+		a, b Tensor
+		res  Tensor
+		err  error
+	}{
+		{a, b, FromNested([][]float64{{7, -1}, {16, -1}}), nil},
+		{b, a, FromNested([][]float64{{1, 2, 3}, {4, 5, 6}, {-2, -1, 0}}), nil},
+		{a, Eye(3), a, nil},
+		{Eye(2), a, a, nil},
+
+		{a, a, Tensor{}, ShapeMismatchError{}},
+		{a, Ones([]int{3}), Tensor{}, LengthMismatchError{}},
+		{Ones([]int{2, 2, 2}), b, Tensor{}, LengthMismatchError{}},
+	}
+
+	for _, tab := range table {
+		res, err := MatMulSafe(tab.a, tab.b)
+
+		_ = handleErrors(t, "MatMul", tab.err, err, "A: %v, B: %v.", tab.a.Dims, tab.b.Dims) &&
+			handleReturn(t, "MatMul", tab.res, res, "A: %v, B: %v.", tab.a.Dims, tab.b.Dims)
+	}
+}
@@ -0,0 +1,294 @@
+package tensors
+
+import "math"
+
+// Optimizer updates a set of named parameters in place, given the gradient of some loss with
+// respect to each of them. The state of each optimizer (eg. momentum) is kept in exported fields,
+// keyed by the name of the parameter, so that optimizers can be serialized with encoding/json (or
+// any other marshaller) to checkpoint and resume training.
+//
+// Step will panic if any of the error conditions from StepSafe are met. StepSafe returns a
+// ParamError if any parameter does not have a gradient (ErrMissingGrad), any gradient does not
+// have a parameter (ErrMissingParam), or any gradient or stored state does not have the same
+// dimensions as its parameter (ShapeMismatchError) or has a different Layout (ErrLayoutMismatch).
+// New state has the dimensions and Layout of its parameter. Parameters are only updated if there
+// are no errors.
+//
+// Parameters and state that are shared (see Tensor.Share) are unshared before they are updated,
+// and the unshared Tensors are stored back in their maps, so Tensors they were shared with are not
+// changed. Because of this, the map is the only reference to a parameter that is guaranteed to be
+// updated: a copy of the Tensor held elsewhere keeps the old Values if it was shared. Parameters
+// should be kept in the map, or read back from it after each Step, eg:
+//	opt.Step(params, grads)
+//	w = params["w"]
+type Optimizer interface {
+	Step(params, grads map[string]Tensor)
+	StepSafe(params, grads map[string]Tensor) error
+}
+
+// checkParams performs the checks shared by every Optimizer. Each of states should map names to
+// state Tensors, which are checked if they exist.
+func checkParams(params, grads map[string]Tensor, states ...map[string]Tensor) error {
+	for name, p := range params {
+		g, ok := grads[name]
+		if !ok {
+			return ParamError{name, ErrMissingGrad}
+		} else if err := checkEquals(p.Interpreter, g.Interpreter, 1); err != nil {
+			return ParamError{name, err}
+		}
+
+		for _, s := range states {
+			if st, ok := s[name]; ok {
+				if err := checkEquals(p.Interpreter, st.Interpreter, 0); err != nil {
+					return ParamError{name, err}
+				}
+			}
+		}
+	}
+
+	for name := range grads {
+		if _, ok := params[name]; !ok {
+			return ParamError{name, ErrMissingParam}
+		}
+	}
+
+	return nil
+}
+
+// stateFor returns the state Tensor for the named parameter, creating a zero'd Tensor (and the map)
+// if it does not yet exist.
+func stateFor(states *map[string]Tensor, name string, p Tensor) Tensor {
+	if *states == nil {
+		*states = make(map[string]Tensor)
+	}
+
+	if _, ok := (*states)[name]; !ok {
+		in := p.Interpreter.Clone()
+		(*states)[name] = Tensor{Interpreter: in, Values: make([]float64, in.Size())}
+	}
+
+	return writable(*states, name)
+}
+
+// writable returns the named Tensor from m, first unsharing it and storing the result back in m,
+// so that it can be updated in place without changing any Tensor that it was shared with. Only
+// the Tensor in m receives the new Values; see the note on Optimizer.
+func writable(m map[string]Tensor, name string) Tensor {
+	t := m[name]
+	t.Unshare()
+	m[name] = t
+	return t
+}
+
+// SGD is stochastic gradient descent, optionally with momentum (classical or Nesterov) and L2
+// weight decay. Each step, for each parameter p with gradient g:
+//		g = g + WeightDecay*p
+//		v = Momentum*v + g
+//		p = p - LearningRate*v                   (or, with Nesterov:)
+//		p = p - LearningRate*(g + Momentum*v)
+// If Momentum is zero, Velocity is not used.
+type SGD struct {
+	LearningRate float64
+	Momentum     float64
+	Nesterov     bool
+	WeightDecay  float64
+
+	// Velocity stores the momentum for each parameter
+	Velocity map[string]Tensor
+}
+
+// NewSGD returns a new SGD Optimizer with the given settings and no weight decay.
+func NewSGD(learningRate, momentum float64, nesterov bool) *SGD {
+	return &SGD{LearningRate: learningRate, Momentum: momentum, Nesterov: nesterov}
+}
+
+// Step performs a single update of the parameters. For more information, see the documentation
+// for Optimizer.
+func (o *SGD) Step(params, grads map[string]Tensor) {
+	if err := o.StepSafe(params, grads); err != nil {
+		panic(err)
+	}
+}
+
+// StepSafe undergoes the same process as Step, but returns error instead of panicking.
+func (o *SGD) StepSafe(params, grads map[string]Tensor) error {
+	if err := checkParams(params, grads, o.Velocity); err != nil {
+		return err
+	}
+
+	for name := range params {
+		p, g := writable(params, name), grads[name]
+
+		var v Tensor
+		if o.Momentum != 0 {
+			v = stateFor(&o.Velocity, name, p)
+		}
+
+		for i := range p.Values {
+			d := g.Values[i] + o.WeightDecay*p.Values[i]
+
+			if o.Momentum != 0 {
+				v.Values[i] = o.Momentum*v.Values[i] + d
+				if o.Nesterov {
+					d += o.Momentum * v.Values[i]
+				} else {
+					d = v.Values[i]
+				}
+			}
+
+			p.Values[i] -= o.LearningRate * d
+		}
+	}
+
+	return nil
+}
+
+// Adam is the Adam optimizer, with AdamW (decoupled weight decay) as an option. Each step, for each
+// parameter p with gradient g:
+//		g = g + WeightDecay*p                    (if not Decoupled)
+//		m = Beta1*m + (1-Beta1)*g
+//		v = Beta2*v + (1-Beta2)*g^2
+//		p = p - LearningRate*WeightDecay*p       (if Decoupled)
+//		p = p - LearningRate * (m / (1-Beta1^t)) / (sqrt(v / (1-Beta2^t)) + Epsilon)
+// where t is the number of steps taken, including the current one.
+type Adam struct {
+	LearningRate float64
+	Beta1        float64
+	Beta2        float64
+	Epsilon      float64
+	WeightDecay  float64
+
+	// Decoupled indicates that weight decay is applied directly to the parameters, as in AdamW,
+	// instead of being added to the gradient.
+	Decoupled bool
+
+	// Steps is the number of steps that have been taken
+	Steps int
+
+	// M and V store the first and second moment estimates for each parameter
+	M map[string]Tensor
+	V map[string]Tensor
+}
+
+// NewAdam returns a new Adam Optimizer with the given learning rate, and the typical defaults for
+// the other settings: Beta1 = 0.9, Beta2 = 0.999, Epsilon = 1e-8, and no weight decay.
+func NewAdam(learningRate float64) *Adam {
+	return &Adam{LearningRate: learningRate, Beta1: 0.9, Beta2: 0.999, Epsilon: 1e-8}
+}
+
+// NewAdamW returns a new Adam Optimizer with decoupled weight decay, as in AdamW. Other settings
+// are the same as with NewAdam.
+func NewAdamW(learningRate, weightDecay float64) *Adam {
+	o := NewAdam(learningRate)
+	o.WeightDecay = weightDecay
+	o.Decoupled = true
+	return o
+}
+
+// Step performs a single update of the parameters. For more information, see the documentation
+// for Optimizer.
+func (o *Adam) Step(params, grads map[string]Tensor) {
+	if err := o.StepSafe(params, grads); err != nil {
+		panic(err)
+	}
+}
+
+// StepSafe undergoes the same process as Step, but returns error instead of panicking.
+func (o *Adam) StepSafe(params, grads map[string]Tensor) error {
+	if err := checkParams(params, grads, o.M, o.V); err != nil {
+		return err
+	}
+
+	o.Steps++
+	correction1 := 1 - math.Pow(o.Beta1, float64(o.Steps))
+	correction2 := 1 - math.Pow(o.Beta2, float64(o.Steps))
+
+	for name := range params {
+		p, g := writable(params, name), grads[name]
+		m, v := stateFor(&o.M, name, p), stateFor(&o.V, name, p)
+
+		for i := range p.Values {
+			d := g.Values[i]
+			if o.Decoupled {
+				p.Values[i] -= o.LearningRate * o.WeightDecay * p.Values[i]
+			} else {
+				d += o.WeightDecay * p.Values[i]
+			}
+
+			m.Values[i] = o.Beta1*m.Values[i] + (1-o.Beta1)*d
+			v.Values[i] = o.Beta2*v.Values[i] + (1-o.Beta2)*d*d
+
+			mHat, vHat := m.Values[i]/correction1, v.Values[i]/correction2
+			p.Values[i] -= o.LearningRate * mHat / (math.Sqrt(vHat) + o.Epsilon)
+		}
+	}
+
+	return nil
+}
+
+// RMSProp is the RMSProp optimizer, optionally with momentum and L2 weight decay. Each step, for
+// each parameter p with gradient g:
+//		g = g + WeightDecay*p
+//		s = Decay*s + (1-Decay)*g^2
+//		v = Momentum*v + g / (sqrt(s) + Epsilon)
+//		p = p - LearningRate*v
+// If Momentum is zero, Velocity is not used, and v is only the current update.
+type RMSProp struct {
+	LearningRate float64
+	Decay        float64
+	Epsilon      float64
+	Momentum     float64
+	WeightDecay  float64
+
+	// Square stores the moving average of the squared gradient for each parameter
+	Square map[string]Tensor
+
+	// Velocity stores the momentum for each parameter
+	Velocity map[string]Tensor
+}
+
+// NewRMSProp returns a new RMSProp Optimizer with the given learning rate, and the typical
+// defaults for the other settings: Decay = 0.99, Epsilon = 1e-8, and no momentum or weight decay.
+func NewRMSProp(learningRate float64) *RMSProp {
+	return &RMSProp{LearningRate: learningRate, Decay: 0.99, Epsilon: 1e-8}
+}
+
+// Step performs a single update of the parameters. For more information, see the documentation
+// for Optimizer.
+func (o *RMSProp) Step(params, grads map[string]Tensor) {
+	if err := o.StepSafe(params, grads); err != nil {
+		panic(err)
+	}
+}
+
+// StepSafe undergoes the same process as Step, but returns error instead of panicking.
+func (o *RMSProp) StepSafe(params, grads map[string]Tensor) error {
+	if err := checkParams(params, grads, o.Square, o.Velocity); err != nil {
+		return err
+	}
+
+	for name := range params {
+		p, g := writable(params, name), grads[name]
+		s := stateFor(&o.Square, name, p)
+
+		var v Tensor
+		if o.Momentum != 0 {
+			v = stateFor(&o.Velocity, name, p)
+		}
+
+		for i := range p.Values {
+			d := g.Values[i] + o.WeightDecay*p.Values[i]
+			s.Values[i] = o.Decay*s.Values[i] + (1-o.Decay)*d*d
+
+			d /= math.Sqrt(s.Values[i]) + o.Epsilon
+			if o.Momentum != 0 {
+				v.Values[i] = o.Momentum*v.Values[i] + d
+				d = v.Values[i]
+			}
+
+			p.Values[i] -= o.LearningRate * d
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package tensors
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// requires FromNested
+func tOptimizer(t *testing.T) {
+	table := []struct {
+		name     string
+		opt      func() Optimizer
+		expected float64
+	}{
+		{"SGD", func() Optimizer { return NewSGD(0.1, 0, false) }, 0.8},
+		{"SGD momentum", func() Optimizer { return NewSGD(0.1, 0.9, false) }, 0.71},
+		{"SGD nesterov", func() Optimizer { return NewSGD(0.1, 0.9, true) }, 0.539},
+		{"Adam", func() Optimizer { return NewAdam(0.1) }, 0.8},
+		{"AdamW", func() Optimizer { return NewAdamW(0.1, 0.1) }, 0.7811},
+		{"RMSProp", func() Optimizer { return NewRMSProp(0.01) },
+			1 - 0.01/math.Sqrt(0.01) - 0.01/math.Sqrt(0.0199)},
+	}
+
+	for _, tab := range table {
+		opt := tab.opt()
+		params := map[string]Tensor{"w": FromNested([]float64{1, 1})}
+		grads := map[string]Tensor{"w": FromNested([]float64{1, 1})}
+
+		for i := 0; i < 2; i++ {
+			if err := opt.StepSafe(params, grads); !handleErrors(t, tab.name, nil, err, "Step: %d.", i) {
+				break
+			}
+		}
+
+		expected := FromNested([]float64{tab.expected, tab.expected})
+		if !approxEqual(expected, params["w"], 1e-6) {
+			t.Errorf("%s: Bad values. Expected %v, Got %v.", tab.name, expected, params["w"])
+		}
+	}
+
+	errTable := []struct {
+		params, grads map[string]Tensor
+		err           error
+	}{
+		{map[string]Tensor{"w": NewTensor([]int{2})}, map[string]Tensor{}, ParamError{}},
+		{map[string]Tensor{}, map[string]Tensor{"w": NewTensor([]int{2})}, ParamError{}},
+		{map[string]Tensor{"w": NewTensor([]int{2})}, map[string]Tensor{"w": NewTensor([]int{3})},
+			ParamError{}},
+	}
+
+	for _, tab := range errTable {
+		err := NewAdam(0.1).StepSafe(tab.params, tab.grads)
+		handleErrors(t, "Optimizer", tab.err, err, "Params: %v, Grads: %v.", tab.params, tab.grads)
+	}
+
+	// stored state must match the parameter
+	opt := NewSGD(0.1, 0.9, false)
+	opt.Velocity = map[string]Tensor{"w": NewTensor([]int{3})}
+	err := opt.StepSafe(map[string]Tensor{"w": NewTensor([]int{2})},
+		map[string]Tensor{"w": NewTensor([]int{2})})
+	handleErrors(t, "Optimizer", ParamError{}, err, "Mismatched state.")
+
+	// shared snapshots of parameters and state must survive a step
+	for _, tab := range table {
+		opt := tab.opt()
+		params := map[string]Tensor{"w": FromNested([]float64{1, 2})}
+		grads := map[string]Tensor{"w": FromNested([]float64{1, 1})}
+		opt.Step(params, grads)
+
+		p := params["w"]
+		snap := p.Share()
+		params["w"] = p
+		before := snap.Clone()
+		opt.Step(params, grads)
+
+		if !handleReturn(t, tab.name, before.Values, snap.Values, "Shared snapshot after Step.") {
+			continue
+		} else if params["w"].IsShared() || approxEqual(before, params["w"], 0) {
+			t.Errorf("%s: Parameter was not updated separately from its snapshot. Got %v.",
+				tab.name, params["w"])
+		}
+	}
+
+	opt = NewSGD(0.5, 0.9, false)
+	params := map[string]Tensor{"w": FromNested([]float64{1, 2})}
+	grads := map[string]Tensor{"w": FromNested([]float64{1, 1})}
+	opt.Step(params, grads)
+
+	v := opt.Velocity["w"]
+	snap := v.Share()
+	opt.Velocity["w"] = v
+	opt.Step(params, grads)
+	handleReturn(t, "SGD", []float64{1, 1}, snap.Values, "Shared state after Step.")
+}
+
+// requires Optimizer
+func tOptimizerCheckpoint(t *testing.T) {
+	grads := map[string]Tensor{
+		"w": FromNested([][]float64{{0.5, -1}, {2, 0.25}}),
+		"b": FromNested([]float64{-3}),
+	}
+
+	newParams := func() map[string]Tensor {
+		return map[string]Tensor{
+			"w": FromNested([][]float64{{1, 2}, {3, 4}}),
+			"b": FromNested([]float64{0.5}),
+		}
+	}
+
+	// train for four steps without interruption, and again with a checkpoint after two
+	full, resumed := newParams(), newParams()
+	a := NewAdamW(0.01, 0.1)
+	for i := 0; i < 4; i++ {
+		a.Step(full, grads)
+	}
+
+	b := NewAdamW(0.01, 0.1)
+	b.Step(resumed, grads)
+	b.Step(resumed, grads)
+
+	data, err := json.Marshal(b)
+	if !handleErrors(t, "OptimizerCheckpoint", nil, err, "Marshal.") {
+		return
+	}
+
+	var c Adam
+	if err := json.Unmarshal(data, &c); !handleErrors(t, "OptimizerCheckpoint", nil, err, "Unmarshal.") {
+		return
+	}
+
+	c.Step(resumed, grads)
+	c.Step(resumed, grads)
+
+	handleReturn(t, "OptimizerCheckpoint", full, resumed, "")
+}
@@ -0,0 +1,225 @@
+package tensors
+
+// PadMode determines how values are chosen for the padding added by Pad.
+type PadMode int
+
+const (
+	// PadConstant fills the padding with a constant value -- zero for Pad, or the value given to
+	// PadWith.
+	PadConstant PadMode = iota
+
+	// PadReflect fills the padding by reflecting the Tensor across its edges, without repeating
+	// the edge itself. Eg. [1 2 3] padded by 2 on each side gives [3 2 1 2 3 2 1]. Padding must be
+	// less than the size of its dimension.
+	PadReflect
+
+	// PadReplicate fills the padding by repeating the values at the edges of the Tensor. Eg. [1 2 3]
+	// padded by 2 on each side gives [1 1 1 2 3 3 3].
+	PadReplicate
+
+	// PadCircular fills the padding by wrapping around to the other side of the Tensor. Eg.
+	// [1 2 3] padded by 2 on each side gives [2 3 1 2 3 1 2]. Padding must be no more than the
+	// size of its dimension.
+	PadCircular
+)
+
+// Pad returns a new Tensor, made from t with padding added to the start (before) and end (after)
+// of each dimension, filled according to mode. Constant padding is filled with zeros. For example,
+// a Tensor with Dims [3, 4] padded with before = [1, 0] and after = [2, 1] has Dims [6, 5].
+//
+// Pad will panic if any of the following conditions are met:
+//		(0) If before or after do not have one value for each dimension. This will cause a
+//			LengthMismatchError.
+//		(1) If any of the padding is negative, or too large for the mode (see PadReflect and
+//			PadCircular). This will cause a PointOutOfBoundsError, where the "point" is the
+//			offending padding, and the "dims" are the limit that it must be less than.
+//		(2) If mode is not one of the defined modes. This will cause ErrPadMode.
+// PadSafe returns these errors instead.
+func Pad(t Tensor, before, after []int, mode PadMode) Tensor {
+	res, err := PadSafe(t, before, after, mode)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// PadSafe undergoes the same process as Pad, but returns error instead of panicking.
+func PadSafe(t Tensor, before, after []int, mode PadMode) (Tensor, error) {
+	res, err := pad(t, before, after, mode, 0)
+	return res, opError("Pad", err, t.Interpreter)
+}
+
+// PadWith returns a new Tensor with constant padding, filled with the given value. It otherwise
+// behaves as Pad does with PadConstant. PadWithSafe returns error instead of panicking.
+func PadWith(t Tensor, before, after []int, value float64) Tensor {
+	res, err := PadWithSafe(t, before, after, value)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// PadWithSafe undergoes the same process as PadWith, but returns error instead of panicking.
+func PadWithSafe(t Tensor, before, after []int, value float64) (Tensor, error) {
+	res, err := pad(t, before, after, PadConstant, value)
+	return res, opError("PadWith", err, t.Interpreter)
+}
+
+// checkPadding checks that before and after are valid padding or cropping for the Tensor, where
+// each value must be less than the corresponding value of limits.
+func (t Tensor) checkPadding(before, after, limits []int) error {
+	if len(before) != len(t.Dims) {
+		return LengthMismatchError{"before", len(before), len(t.Dims)}
+	} else if len(after) != len(t.Dims) {
+		return LengthMismatchError{"after", len(after), len(t.Dims)}
+	}
+
+	for i := range t.Dims {
+		if before[i] < 0 || before[i] >= limits[i] {
+			return PointOutOfBoundsError{before, limits, i}
+		} else if after[i] < 0 || after[i] >= limits[i] {
+			return PointOutOfBoundsError{after, limits, i}
+		}
+	}
+
+	return nil
+}
+
+// pad is the shared implementation of PadSafe and PadWithSafe.
+func pad(t Tensor, before, after []int, mode PadMode, value float64) (Tensor, error) {
+	if err := checkLayout(t.Interpreter); err != nil {
+		return Tensor{}, err
+	}
+
+	limits := make([]int, len(t.Dims))
+	for i, d := range t.Dims {
+		switch mode {
+		case PadConstant, PadReplicate:
+			limits[i] = maxInt
+		case PadReflect:
+			limits[i] = d
+		case PadCircular:
+			limits[i] = d + 1
+		default:
+			return Tensor{}, ErrPadMode
+		}
+	}
+
+	if err := t.checkPadding(before, after, limits); err != nil {
+		return Tensor{}, err
+	}
+
+	dims := make([]int, len(t.Dims))
+	for i, d := range t.Dims {
+		dims[i] = before[i] + d + after[i]
+	}
+
+	res, err := NewTensorSafe(dims)
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	// for each axis, the offset in t.Values of each position along that axis in the result, or -1
+	// if it is constant padding
+	offsets := make([][]int, len(dims))
+	for a := range dims {
+		d := t.Dims[a]
+		offsets[a] = make([]int, dims[a])
+
+		for p := range offsets[a] {
+			c := p - before[a]
+			if c < 0 || c >= d {
+				switch mode {
+				case PadConstant:
+					offsets[a][p] = -1
+					continue
+				case PadReflect:
+					if c < 0 {
+						c = -c
+					} else {
+						c = 2*(d-1) - c
+					}
+				case PadReplicate:
+					if c < 0 {
+						c = 0
+					} else {
+						c = d - 1
+					}
+				case PadCircular:
+					c = (c + d) % d
+				}
+			}
+
+			offsets[a][p] = c * t.stride(a)
+		}
+	}
+
+	point := make([]int, len(dims))
+	for i := range res.Values {
+		index := 0
+		for a, p := range point {
+			if offsets[a][p] == -1 {
+				index = -1
+				break
+			}
+
+			index += offsets[a][p]
+		}
+
+		if index == -1 {
+			res.Values[i] = value
+		} else {
+			res.Values[i] = t.Values[index]
+		}
+
+		res.IncrementFast(point)
+	}
+
+	return res, nil
+}
+
+// Crop returns a new Tensor, made from t with the given number of values removed from the start
+// (before) and end (after) of each dimension. It is the inverse of Pad.
+//
+// Crop will panic with a LengthMismatchError if before or after do not have one value for each
+// dimension, or a PointOutOfBoundsError if any of them are negative, or would remove every value
+// along their dimension. CropSafe returns these errors instead.
+func Crop(t Tensor, before, after []int) Tensor {
+	res, err := CropSafe(t, before, after)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// CropSafe undergoes the same process as Crop, but returns error instead of panicking.
+func CropSafe(t Tensor, before, after []int) (Tensor, error) {
+	if err := checkLayout(t.Interpreter); err != nil {
+		return Tensor{}, opError("Crop", err, t.Interpreter)
+	} else if err := t.checkPadding(before, after, t.Dims); err != nil {
+		return Tensor{}, opError("Crop", err, t.Interpreter)
+	}
+
+	dims := make([]int, len(t.Dims))
+	for i, d := range t.Dims {
+		dims[i] = d - before[i] - after[i]
+		if dims[i] <= 0 {
+			return Tensor{}, opError("Crop", PointOutOfBoundsError{after, t.Dims, i}, t.Interpreter)
+		}
+	}
+
+	res := NewTensor(dims)
+	start := t.IndexFast(before)
+
+	point := make([]int, len(dims))
+	for i := range res.Values {
+		// the point in t is offset by before, so its index is offset by the index of before
+		res.Values[i] = t.Values[start+t.IndexFast(point)]
+		res.IncrementFast(point)
+	}
+
+	return res, nil
+}
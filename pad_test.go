@@ -0,0 +1,80 @@
+package tensors
+
+import (
+	"testing"
+)
+
+// requires FromNested
+func tPad(t *testing.T) {
+	seq := FromNested([]float64{1, 2, 3})
+	matrix := FromNested([][]float64{{1, 2}, {3, 4}})
+
+	table := []struct {
+		t             Tensor
+		before, after []int
+		mode          PadMode
+		res           Tensor
+		err           error
+	}{
+		{seq, []int{2}, []int{1}, PadConstant, FromNested([]float64{0, 0, 1, 2, 3, 0}), nil},
+		{seq, []int{2}, []int{2}, PadReflect, FromNested([]float64{3, 2, 1, 2, 3, 2, 1}), nil},
+		{seq, []int{2}, []int{2}, PadReplicate, FromNested([]float64{1, 1, 1, 2, 3, 3, 3}), nil},
+		{seq, []int{2}, []int{3}, PadCircular, FromNested([]float64{2, 3, 1, 2, 3, 1, 2, 3}), nil},
+		{seq, []int{0}, []int{0}, PadReflect, seq, nil},
+		{matrix, []int{1, 0}, []int{0, 1}, PadReplicate, FromNested([][]float64{{1, 1, 2}, {3, 3, 4}, {3, 3, 4}}), nil},
+		{matrix, []int{1, 1}, []int{1, 1}, PadReflect,
+			FromNested([][]float64{{4, 3, 4, 3}, {2, 1, 2, 1}, {4, 3, 4, 3}, {2, 1, 2, 1}}), nil},
+
+		{seq, []int{1, 1}, []int{1}, PadConstant, Tensor{}, LengthMismatchError{}},
+		{seq, []int{1}, nil, PadConstant, Tensor{}, LengthMismatchError{}},
+		{seq, []int{-1}, []int{0}, PadConstant, Tensor{}, PointOutOfBoundsError{}},
+		{seq, []int{0}, []int{3}, PadReflect, Tensor{}, PointOutOfBoundsError{}},
+		{seq, []int{4}, []int{0}, PadCircular, Tensor{}, PointOutOfBoundsError{}},
+		{seq, []int{0}, []int{0}, PadMode(7), Tensor{}, ErrPadMode},
+	}
+
+	for _, tab := range table {
+		res, err := PadSafe(tab.t, tab.before, tab.after, tab.mode)
+
+		format := "Tensor: %v, Before: %v, After: %v, Mode: %v."
+		a := []interface{}{tab.t, tab.before, tab.after, tab.mode}
+		_ = handleErrors(t, "Pad", tab.err, err, format, a...) &&
+			handleReturn(t, "Pad", tab.res, res, format, a...)
+	}
+
+	res, err := PadWithSafe(seq, []int{1}, []int{1}, -1)
+	_ = handleErrors(t, "PadWith", nil, err, "") &&
+		handleReturn(t, "PadWith", FromNested([]float64{-1, 1, 2, 3, -1}), res, "")
+}
+
+// requires Pad
+func tCrop(t *testing.T) {
+	matrix := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+
+	table := []struct {
+		before, after []int
+		res           Tensor
+		err           error
+	}{
+		{[]int{1, 0}, []int{0, 1}, FromNested([][]float64{{2, 3}, {5, 6}}), nil},
+		{[]int{1, 1}, []int{1, 1}, FromNested([][]float64{{5}}), nil},
+		{[]int{0, 0}, []int{0, 0}, matrix, nil},
+
+		{[]int{0}, []int{0, 0}, Tensor{}, LengthMismatchError{}},
+		{[]int{0, -1}, []int{0, 0}, Tensor{}, PointOutOfBoundsError{}},
+		{[]int{0, 0}, []int{3, 0}, Tensor{}, PointOutOfBoundsError{}},
+		{[]int{2, 0}, []int{1, 0}, Tensor{}, PointOutOfBoundsError{}},
+	}
+
+	for _, tab := range table {
+		res, err := CropSafe(matrix, tab.before, tab.after)
+
+		_ = handleErrors(t, "Crop", tab.err, err, "Before: %v, After: %v.", tab.before, tab.after) &&
+			handleReturn(t, "Crop", tab.res, res, "Before: %v, After: %v.", tab.before, tab.after)
+	}
+
+	for _, mode := range []PadMode{PadConstant, PadReflect, PadReplicate, PadCircular} {
+		padded := Pad(matrix, []int{1, 2}, []int{2, 0}, mode)
+		handleReturn(t, "Crop", matrix, Crop(padded, []int{1, 2}, []int{2, 0}), "Crop did not invert Pad. Mode: %v.", mode)
+	}
+}
@@ -0,0 +1,323 @@
+package tensors
+
+import "math"
+
+// PoolOptions serves as an argument to MaxPool and AvgPool, giving the shape of the pooling
+// window. Each of Kernel, Stride and Padding has one value for each pooled axis. Kernel is
+// required, so a nil *PoolOptions, which is treated as the zero value, gives a LengthMismatchError.
+type PoolOptions struct {
+	// Kernel is the size of the pooling window along each pooled axis. Each value must be ≥ 1.
+	Kernel []int
+
+	// Stride is the distance between neighboring windows along each pooled axis. If Stride is
+	// nil, it is set equal to Kernel, so that windows do not overlap. Each value must be ≥ 1.
+	Stride []int
+
+	// Padding is the number of implicit values added to both ends of each pooled axis. Padded
+	// values are never selected by MaxPool, and are not counted by AvgPool. If Padding is nil, it
+	// is set to zero. Each value must be ≥ 0 and no more than half of the kernel.
+	Padding []int
+}
+
+// poolWindow stores the information about the shapes involved in pooling, shared between MaxPool
+// and AvgPool.
+type poolWindow struct {
+	axes    []int
+	stride  []int
+	padding []int
+
+	// kernel is an Interpreter over the pooled axes of a single window
+	kernel Interpreter
+
+	// out is the Interpreter of the result of the pooling
+	out Interpreter
+}
+
+// newPoolWindow checks the axes and options given, returning the pooling window.
+func (t Tensor) newPoolWindow(axes []int, opts *PoolOptions) (poolWindow, error) {
+	if opts == nil {
+		opts = &PoolOptions{}
+	}
+
+	if err := checkLayout(t.Interpreter); err != nil {
+		return poolWindow{}, err
+	}
+
+	for i, a := range axes {
+		if err := t.checkAxis(a); err != nil {
+			return poolWindow{}, err
+		}
+
+		for _, b := range axes[:i] {
+			if a == b {
+				return poolWindow{}, ErrDuplicateAxis
+			}
+		}
+	}
+
+	if len(opts.Kernel) != len(axes) {
+		return poolWindow{}, LengthMismatchError{"kernel", len(opts.Kernel), len(axes)}
+	}
+
+	w := poolWindow{axes: axes, stride: opts.Stride, padding: opts.Padding}
+	if w.stride == nil {
+		w.stride = opts.Kernel
+	} else if len(w.stride) != len(axes) {
+		return poolWindow{}, LengthMismatchError{"stride", len(w.stride), len(axes)}
+	}
+
+	if w.padding == nil {
+		w.padding = make([]int, len(axes))
+	} else if len(w.padding) != len(axes) {
+		return poolWindow{}, LengthMismatchError{"padding", len(w.padding), len(axes)}
+	}
+
+	outDims := make([]int, len(t.Dims))
+	copy(outDims, t.Dims)
+
+	for i, a := range axes {
+		k, s, p := opts.Kernel[i], w.stride[i], w.padding[i]
+		if k < 1 || s < 1 || p < 0 || 2*p > k {
+			return poolWindow{}, ErrPoolOptions
+		} else if t.Dims[a]+2*p < k {
+			return poolWindow{}, ErrPoolOutputSize
+		}
+
+		outDims[a] = (t.Dims[a]+2*p-k)/s + 1
+	}
+
+	var err error
+	if w.kernel, err = NewInterpreterSafe(opts.Kernel); err != nil {
+		return poolWindow{}, err
+	}
+
+	w.out = NewInterpreter(outDims)
+	return w, nil
+}
+
+// each calls fn for every window, giving the index of the window in the output and the indices of
+// every value in the window that is not in the padding.
+func (w poolWindow) each(t Tensor, fn func(out int, window []int)) {
+	pooled := make([]bool, len(t.Dims))
+	for _, a := range w.axes {
+		pooled[a] = true
+	}
+
+	strides := make([]int, len(w.axes))
+	for i, a := range w.axes {
+		strides[i] = t.stride(a)
+	}
+
+	point := make([]int, len(t.Dims))
+	start := make([]int, len(w.axes))
+	kernelPoint := make([]int, len(w.axes))
+	window := make([]int, 0, w.kernel.Size())
+
+	for out := 0; out < w.out.Size(); out++ {
+		// the index of the non-pooled axes, which are constant over the window
+		base := 0
+		for a, p := range point {
+			if !pooled[a] {
+				base += p * t.stride(a)
+			}
+		}
+
+		for i, a := range w.axes {
+			start[i] = point[a]*w.stride[i] - w.padding[i]
+		}
+
+		window = window[:0]
+		for k := 0; k < w.kernel.Size(); k++ {
+			index := base
+			for i, a := range w.axes {
+				c := start[i] + kernelPoint[i]
+				if c < 0 || c >= t.Dims[a] {
+					index = -1
+					break
+				}
+
+				index += c * strides[i]
+			}
+
+			if index != -1 {
+				window = append(window, index)
+			}
+
+			w.kernel.IncrementFast(kernelPoint)
+		}
+
+		fn(out, window)
+		w.out.IncrementFast(point)
+	}
+}
+
+// MaxPool returns the maximum value in each window along the given axes, along with the indices in
+// t.Values of each selected value. Axes that are not pooled are left unchanged. The size of the
+// result along each pooled axis is:
+//		(Dims[axis] + 2*padding - kernel) / stride + 1
+//
+// The returned Indices have the same dimensions as the result, and are intended for use with
+// MaxPoolGrad. If multiple values in a window are equal to the maximum, the one with the lowest
+// index is selected.
+//
+// MaxPool will panic if any of the following conditions are met:
+//		(0) If any of the axes are out of bounds, this will cause an AxisError.
+//		(1) If any of the axes are repeated, this will cause ErrDuplicateAxis.
+//		(2) If any of the options do not have one value for each axis, this will cause a
+//			LengthMismatchError.
+//		(3) If any of the options are out of range, this will cause ErrPoolOptions.
+//		(4) If the kernel is larger than the padded Tensor, this will cause ErrPoolOutputSize.
+// MaxPoolSafe returns these errors instead.
+func (t Tensor) MaxPool(axes []int, opts *PoolOptions) (Tensor, Indices) {
+	res, argmax, err := t.MaxPoolSafe(axes, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return res, argmax
+}
+
+// MaxPoolSafe undergoes the same process as MaxPool, but returns error instead of panicking.
+func (t Tensor) MaxPoolSafe(axes []int, opts *PoolOptions) (Tensor, Indices, error) {
+	w, err := t.newPoolWindow(axes, opts)
+	if err != nil {
+		return Tensor{}, Indices{}, opError("MaxPool", err, t.Interpreter)
+	}
+
+	res := Tensor{Interpreter: w.out, Values: make([]float64, w.out.Size())}
+	argmax := Indices{w.out, make([]int, w.out.Size())}
+
+	w.each(t, func(out int, window []int) {
+		max, maxIndex := math.Inf(-1), window[0]
+		for _, index := range window {
+			if t.Values[index] > max {
+				max, maxIndex = t.Values[index], index
+			}
+		}
+
+		res.Values[out] = t.Values[maxIndex]
+		argmax.Values[out] = maxIndex
+	})
+
+	return res, argmax, nil
+}
+
+// MaxPoolGrad returns the gradient with respect to the input of MaxPool, given the gradient with
+// respect to its output and the Indices that it returned. Each gradient is routed to the value
+// that was selected; all others are zero. The result has the given input dimensions.
+//
+// MaxPoolGrad will panic with a ShapeMismatchError if gradOut and argmax do not have the same
+// dimensions, an error from NewInterpreterSafe if inputDims are invalid, or ErrIndexZero or
+// ErrIndexSize if any of the indices are out of bounds of inputDims. MaxPoolGradSafe returns these
+// errors instead.
+func MaxPoolGrad(gradOut Tensor, argmax Indices, inputDims []int) Tensor {
+	t, err := MaxPoolGradSafe(gradOut, argmax, inputDims)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// MaxPoolGradSafe undergoes the same process as MaxPoolGrad, but returns error instead of
+// panicking.
+func MaxPoolGradSafe(gradOut Tensor, argmax Indices, inputDims []int) (Tensor, error) {
+	if err := checkLayout(gradOut.Interpreter, argmax.Interpreter); err != nil {
+		return Tensor{}, opError("MaxPoolGrad", err, gradOut.Interpreter, argmax.Interpreter)
+	} else if !Equals(gradOut.Interpreter, argmax.Interpreter) {
+		err := ShapeMismatchError{1, -1, argmax.Dims, gradOut.Dims}
+		return Tensor{}, opError("MaxPoolGrad", err, gradOut.Interpreter, argmax.Interpreter)
+	}
+
+	grad, err := NewTensorSafe(inputDims)
+	if err != nil {
+		return Tensor{}, opError("MaxPoolGrad", err, gradOut.Interpreter, argmax.Interpreter)
+	}
+
+	for i, index := range argmax.Values {
+		if err := grad.CheckIndex(index); err != nil {
+			return Tensor{}, opError("MaxPoolGrad", err, gradOut.Interpreter, argmax.Interpreter)
+		}
+
+		grad.Values[index] += gradOut.Values[i]
+	}
+
+	return grad, nil
+}
+
+// AvgPool returns the mean of the values in each window along the given axes. Padded values are
+// not included in the mean. AvgPool will panic under the same conditions as MaxPool; AvgPoolSafe
+// returns these errors instead.
+func (t Tensor) AvgPool(axes []int, opts *PoolOptions) Tensor {
+	res, err := t.AvgPoolSafe(axes, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// AvgPoolSafe undergoes the same process as AvgPool, but returns error instead of panicking.
+func (t Tensor) AvgPoolSafe(axes []int, opts *PoolOptions) (Tensor, error) {
+	w, err := t.newPoolWindow(axes, opts)
+	if err != nil {
+		return Tensor{}, opError("AvgPool", err, t.Interpreter)
+	}
+
+	res := Tensor{Interpreter: w.out, Values: make([]float64, w.out.Size())}
+	w.each(t, func(out int, window []int) {
+		var sum float64
+		for _, index := range window {
+			sum += t.Values[index]
+		}
+
+		res.Values[out] = sum / float64(len(window))
+	})
+
+	return res, nil
+}
+
+// AvgPoolGrad returns the gradient with respect to the input of AvgPool, given the gradient with
+// respect to its output. The axes and options must be the same as those given to AvgPool.
+//
+// AvgPoolGrad will panic under the same conditions as AvgPool, or with a ShapeMismatchError if
+// gradOut does not have the dimensions of the output of AvgPool. AvgPoolGradSafe returns these
+// errors instead.
+func AvgPoolGrad(gradOut Tensor, inputDims, axes []int, opts *PoolOptions) Tensor {
+	t, err := AvgPoolGradSafe(gradOut, inputDims, axes, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// AvgPoolGradSafe undergoes the same process as AvgPoolGrad, but returns error instead of
+// panicking.
+func AvgPoolGradSafe(gradOut Tensor, inputDims, axes []int, opts *PoolOptions) (Tensor, error) {
+	if err := checkLayout(gradOut.Interpreter); err != nil {
+		return Tensor{}, opError("AvgPoolGrad", err, gradOut.Interpreter)
+	}
+
+	grad, err := NewTensorSafe(inputDims)
+	if err != nil {
+		return Tensor{}, opError("AvgPoolGrad", err, gradOut.Interpreter)
+	}
+
+	w, err := grad.newPoolWindow(axes, opts)
+	if err != nil {
+		return Tensor{}, opError("AvgPoolGrad", err, gradOut.Interpreter)
+	} else if !Equals(gradOut.Interpreter, w.out) {
+		return Tensor{}, opError("AvgPoolGrad", ShapeMismatchError{0, -1, gradOut.Dims, w.out.Dims},
+			gradOut.Interpreter)
+	}
+
+	w.each(grad, func(out int, window []int) {
+		g := gradOut.Values[out] / float64(len(window))
+		for _, index := range window {
+			grad.Values[index] += g
+		}
+	})
+
+	return grad, nil
+}
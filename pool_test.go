@@ -0,0 +1,82 @@
+package tensors
+
+import (
+	"testing"
+)
+
+// requires FromNested, Gather
+func tPool(t *testing.T) {
+	tensor := FromNested([][]float64{
+		{1, 5, 2, 0},
+		{3, 4, 8, 7},
+		{0, 6, 1, 2},
+	})
+
+	table := []struct {
+		axes   []int
+		opts   *PoolOptions
+		max    Tensor
+		argmax []int
+		avg    Tensor
+		err    error
+	}{
+		{[]int{0, 1}, &PoolOptions{Kernel: []int{2, 2}},
+			FromNested([][]float64{{5, 8}}), []int{1, 6},
+			FromNested([][]float64{{3.25, 4.25}}), nil},
+		{[]int{0}, &PoolOptions{Kernel: []int{2}, Stride: []int{1}},
+			FromNested([][]float64{{5, 5, 2}, {4, 8, 8}, {6, 6, 2}}), []int{1, 1, 2, 5, 6, 6, 9, 9, 11},
+			FromNested([][]float64{{3, 3.5, 1}, {3.5, 6, 7.5}, {3, 3.5, 1.5}}), nil},
+		{[]int{1}, &PoolOptions{Kernel: []int{2}, Padding: []int{1}},
+			FromNested([][]float64{{1, 5, 2, 0}, {3, 6, 8, 7}}), []int{0, 1, 2, 3, 4, 9, 6, 7},
+			FromNested([][]float64{{1, 5, 2, 0}, {1.5, 5, 4.5, 4.5}}), nil},
+
+		{[]int{2}, &PoolOptions{Kernel: []int{2}}, Tensor{}, nil, Tensor{}, AxisError{}},
+		{[]int{0, 0}, &PoolOptions{Kernel: []int{2, 2}}, Tensor{}, nil, Tensor{}, ErrDuplicateAxis},
+		{[]int{0}, &PoolOptions{Kernel: []int{2, 2}}, Tensor{}, nil, Tensor{}, LengthMismatchError{}},
+		{[]int{0}, nil, Tensor{}, nil, Tensor{}, LengthMismatchError{}},
+		{[]int{0}, &PoolOptions{Kernel: []int{2}, Stride: []int{}}, Tensor{}, nil, Tensor{}, LengthMismatchError{}},
+		{[]int{0}, &PoolOptions{Kernel: []int{0}}, Tensor{}, nil, Tensor{}, ErrPoolOptions},
+		{[]int{0}, &PoolOptions{Kernel: []int{2}, Padding: []int{2}}, Tensor{}, nil, Tensor{}, ErrPoolOptions},
+		{[]int{1}, &PoolOptions{Kernel: []int{4}}, Tensor{}, nil, Tensor{}, ErrPoolOutputSize},
+	}
+
+	for _, tab := range table {
+		max, argmax, err := tensor.MaxPoolSafe(tab.axes, tab.opts)
+		if handleErrors(t, "MaxPool", tab.err, err, "Axes: %v, Options: %+v.", tab.axes, tab.opts) {
+			handleReturn(t, "MaxPool", tab.max, max, "Axes: %v, Options: %+v.", tab.axes, tab.opts)
+			handleReturn(t, "MaxPool", tab.argmax, argmax.Values, "Axes: %v, Options: %+v.", tab.axes, tab.opts)
+		}
+
+		avg, err := tensor.AvgPoolSafe(tab.axes, tab.opts)
+		_ = handleErrors(t, "AvgPool", tab.err, err, "Axes: %v, Options: %+v.", tab.axes, tab.opts) &&
+			handleReturn(t, "AvgPool", tab.avg, avg, "Axes: %v, Options: %+v.", tab.axes, tab.opts)
+	}
+}
+
+// requires Pool, Constructors
+func tPoolGrad(t *testing.T) {
+	tensor := FromNested([][]float64{
+		{1, 5, 2, 0},
+		{3, 4, 8, 7},
+		{0, 6, 1, 2},
+	})
+
+	axes, opts := []int{0}, &PoolOptions{Kernel: []int{2}, Stride: []int{1}}
+	max, argmax := tensor.MaxPool(axes, opts)
+
+	grad, err := MaxPoolGradSafe(Ones(max.Dims), argmax, tensor.Dims)
+	_ = handleErrors(t, "MaxPoolGrad", nil, err, "") &&
+		handleReturn(t, "MaxPoolGrad", FromNested([][]float64{{0, 2, 1, 0}, {0, 1, 2, 0}, {0, 2, 0, 1}}), grad, "")
+
+	_, err = MaxPoolGradSafe(Ones([]int{2}), argmax, tensor.Dims)
+	handleErrors(t, "MaxPoolGrad", ShapeMismatchError{}, err, "")
+	_, err = MaxPoolGradSafe(Ones(max.Dims), argmax, []int{2, 2})
+	handleErrors(t, "MaxPoolGrad", ErrIndexSize, err, "")
+
+	grad, err = AvgPoolGradSafe(Ones([]int{4, 2}), tensor.Dims, []int{1}, &PoolOptions{Kernel: []int{2}, Padding: []int{1}})
+	_ = handleErrors(t, "AvgPoolGrad", nil, err, "") &&
+		handleReturn(t, "AvgPoolGrad", FromNested([][]float64{{1, 1, 1, 1}, {0.5, 0.5, 0.5, 0.5}, {0.5, 0.5, 0.5, 0.5}}), grad, "")
+
+	_, err = AvgPoolGradSafe(Ones([]int{3}), tensor.Dims, axes, opts)
+	handleErrors(t, "AvgPoolGrad", ShapeMismatchError{}, err, "")
+}
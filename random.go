@@ -0,0 +1,201 @@
+package tensors
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// Sampler is a source of random values following a particular distribution. Samplers are given to
+// NewRandom and Tensor.Fill, and obtain all of their randomness from the provided *rand.Rand, so
+// that results are reproducible for a given seed.
+type Sampler func(rng *rand.Rand) float64
+
+// randomChunkSize is the number of values filled from each derived seed by FillParallel. It is
+// fixed so that the output does not depend on the ThreadingOptions given.
+const randomChunkSize = 4096
+
+// Uniform returns a Sampler that gives values uniformly distributed in [low, high).
+func Uniform(low, high float64) Sampler {
+	return func(rng *rand.Rand) float64 {
+		return low + rng.Float64()*(high-low)
+	}
+}
+
+// Normal returns a Sampler that gives normally distributed values with the given mean and standard
+// deviation.
+func Normal(mean, std float64) Sampler {
+	return func(rng *rand.Rand) float64 {
+		return mean + rng.NormFloat64()*std
+	}
+}
+
+// TruncatedNormal returns a Sampler that gives normally distributed values with the given mean and
+// standard deviation, except that values more than two standard deviations from the mean are
+// discarded and drawn again.
+func TruncatedNormal(mean, std float64) Sampler {
+	return func(rng *rand.Rand) float64 {
+		for {
+			if v := rng.NormFloat64(); math.Abs(v) <= 2 {
+				return mean + v*std
+			}
+		}
+	}
+}
+
+// XavierUniform returns a Sampler for Xavier (also called Glorot) initialization with a uniform
+// distribution, where fanIn and fanOut are the number of inputs to and outputs from the layer
+// being initialized. Values are in the range ±sqrt(6 / (fanIn + fanOut)).
+func XavierUniform(fanIn, fanOut int) Sampler {
+	limit := math.Sqrt(6 / float64(fanIn+fanOut))
+	return Uniform(-limit, limit)
+}
+
+// XavierNormal returns a Sampler for Xavier (also called Glorot) initialization with a normal
+// distribution, with mean 0 and standard deviation sqrt(2 / (fanIn + fanOut)).
+func XavierNormal(fanIn, fanOut int) Sampler {
+	return Normal(0, math.Sqrt(2/float64(fanIn+fanOut)))
+}
+
+// HeUniform returns a Sampler for He initialization with a uniform distribution, where fanIn is
+// the number of inputs to the layer being initialized. Values are in the range ±sqrt(6 / fanIn).
+func HeUniform(fanIn int) Sampler {
+	limit := math.Sqrt(6 / float64(fanIn))
+	return Uniform(-limit, limit)
+}
+
+// HeNormal returns a Sampler for He initialization with a normal distribution, with mean 0 and
+// standard deviation sqrt(2 / fanIn).
+func HeNormal(fanIn int) Sampler {
+	return Normal(0, math.Sqrt(2/float64(fanIn)))
+}
+
+// NewRandom returns a new Tensor with the given dimensions, filled with values drawn from the
+// Sampler, in order of increasing index. NewRandom will panic if any of the error conditions from
+// NewRandomSafe are met.
+func NewRandom(dims []int, s Sampler, rng *rand.Rand) Tensor {
+	t, err := NewRandomSafe(dims, s, rng)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// NewRandomSafe undergoes the same process as NewRandom, but returns error instead of panicking.
+// In addition to the errors from NewTensorSafe, NewRandomSafe will return ErrNilSampler or
+// ErrNilRand if either of s or rng are nil.
+func NewRandomSafe(dims []int, s Sampler, rng *rand.Rand) (Tensor, error) {
+	t, err := NewTensorSafe(dims)
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	if err = t.FillSafe(s, rng); err != nil {
+		return Tensor{}, err
+	}
+
+	return t, nil
+}
+
+// Fill sets every value of the Tensor to one drawn from the Sampler, in order of increasing
+// index. Fill will panic with ErrNilSampler or ErrNilRand if either s or rng are nil.
+func (t *Tensor) Fill(s Sampler, rng *rand.Rand) {
+	if err := t.FillSafe(s, rng); err != nil {
+		panic(err)
+	}
+}
+
+// FillSafe undergoes the same process as Fill, but returns error instead of panicking.
+func (t *Tensor) FillSafe(s Sampler, rng *rand.Rand) error {
+	if s == nil {
+		return ErrNilSampler
+	} else if rng == nil {
+		return ErrNilRand
+	}
+
+	t.Unshare()
+	for i := range t.Values {
+		t.Values[i] = s(rng)
+	}
+
+	return nil
+}
+
+// FillParallel sets every value of the Tensor to one drawn from the Sampler, using multiple
+// goroutines as configured by options. If options is nil, FillParallel will run as a single
+// thread.
+//
+// Values are divided into fixed-size chunks, each of which is filled by its own *rand.Rand with a
+// seed derived from the given seed and the position of the chunk. Because of this, the values
+// depend only on the seed -- not on options -- so runs are reproducible regardless of the number
+// of threads. Only options.NumThreads is used; OpsPerThread is ignored. Note that the values will
+// differ from those given by Fill with a *rand.Rand from the same seed.
+//
+// FillParallel will panic with ErrNilSampler if s is nil.
+func (t *Tensor) FillParallel(s Sampler, seed int64, options *ThreadingOptions) {
+	if err := t.FillParallelSafe(s, seed, options); err != nil {
+		panic(err)
+	}
+}
+
+// FillParallelSafe undergoes the same process as FillParallel, but returns error instead of
+// panicking.
+func (t *Tensor) FillParallelSafe(s Sampler, seed int64, options *ThreadingOptions) error {
+	if s == nil {
+		return ErrNilSampler
+	}
+
+	t.Unshare()
+	numThreads := 1
+	if options != nil && options.NumThreads > 1 {
+		numThreads = options.NumThreads
+	}
+
+	numChunks := (len(t.Values) + randomChunkSize - 1) / randomChunkSize
+
+	var mux sync.Mutex
+	var wg sync.WaitGroup
+	var next int
+
+	wg.Add(numThreads)
+	for thread := 0; thread < numThreads; thread++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				mux.Lock()
+				chunk := next
+				next++
+				mux.Unlock()
+
+				if chunk >= numChunks {
+					return
+				}
+
+				rng := rand.New(rand.NewSource(chunkSeed(seed, chunk)))
+
+				end := (chunk + 1) * randomChunkSize
+				if end > len(t.Values) {
+					end = len(t.Values)
+				}
+
+				for i := chunk * randomChunkSize; i < end; i++ {
+					t.Values[i] = s(rng)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// chunkSeed derives the seed for a single chunk of FillParallel, using the SplitMix64 finalizer
+// so that seeds for neighboring chunks are uncorrelated.
+func chunkSeed(seed int64, chunk int) int64 {
+	z := uint64(seed) + uint64(chunk+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
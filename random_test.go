@@ -0,0 +1,81 @@
+package tensors
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// requires NewInterpreter
+func tRandom(t *testing.T) {
+	table := []struct {
+		name    string
+		s       Sampler
+		low, hi float64
+	}{
+		{"Uniform", Uniform(-2, 3), -2, 3},
+		{"TruncatedNormal", TruncatedNormal(1, 0.5), 0, 2},
+		{"XavierUniform", XavierUniform(4, 2), -1, 1},
+		{"HeUniform", HeUniform(24), -0.5, 0.5},
+	}
+
+	for _, tab := range table {
+		tensor := NewRandom([]int{10, 10}, tab.s, rand.New(rand.NewSource(1)))
+		for i, v := range tensor.Values {
+			if v < tab.low || v > tab.hi {
+				t.Errorf("%s: Value out of range. Index: %d, Expected %v ≤ v ≤ %v, Got %v.",
+					tab.name, i, tab.low, tab.hi, v)
+			}
+		}
+	}
+
+	// a large sample should have roughly the right mean and standard deviation
+	tensor := NewRandom([]int{100, 100}, Normal(3, 2), rand.New(rand.NewSource(1)))
+	var mean, variance float64
+	for _, v := range tensor.Values {
+		mean += v
+	}
+	mean /= float64(tensor.Size())
+	for _, v := range tensor.Values {
+		variance += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(variance / float64(tensor.Size()))
+
+	if math.Abs(mean-3) > 0.1 || math.Abs(std-2) > 0.1 {
+		t.Errorf("Normal: Bad distribution. Expected mean 3 and std 2, Got mean %v and std %v.", mean, std)
+	}
+
+	// the same seed must give the same values
+	a := NewRandom([]int{5, 5}, HeNormal(3), rand.New(rand.NewSource(7)))
+	b := NewRandom([]int{5, 5}, HeNormal(3), rand.New(rand.NewSource(7)))
+	handleReturn(t, "NewRandom", a.Values, b.Values, "Same seed gave different values.")
+
+	_, err := NewRandomSafe([]int{1}, nil, rand.New(rand.NewSource(1)))
+	handleErrors(t, "NewRandom", ErrNilSampler, err, "")
+	_, err = NewRandomSafe([]int{1}, Uniform(0, 1), nil)
+	handleErrors(t, "NewRandom", ErrNilRand, err, "")
+}
+
+// requires Random
+func tFillParallel(t *testing.T) {
+	// enough values that there are several chunks, with one left partially filled
+	dims := []int{3*randomChunkSize + 10}
+
+	base := NewTensor(dims)
+	base.FillParallel(XavierNormal(3, 4), 42, nil)
+
+	for _, options := range []*ThreadingOptions{{NumThreads: 1}, {NumThreads: 3}, {NumThreads: 8, OpsPerThread: 5}} {
+		tensor := NewTensor(dims)
+		tensor.FillParallel(XavierNormal(3, 4), 42, options)
+
+		handleReturn(t, "FillParallel", base.Values, tensor.Values, "Options: %+v.", *options)
+	}
+
+	other := NewTensor(dims)
+	other.FillParallel(XavierNormal(3, 4), 43, nil)
+	if other.Values[0] == base.Values[0] && other.Values[randomChunkSize] == base.Values[randomChunkSize] {
+		t.Errorf("FillParallel: Different seeds gave the same values.")
+	}
+
+	handleErrors(t, "FillParallel", ErrNilSampler, base.FillParallelSafe(nil, 1, nil), "")
+}
@@ -0,0 +1,428 @@
+package tensors
+
+import (
+	"sort"
+)
+
+// COO is a sparse Tensor in coordinate format, storing only its nonzero values, for any number of
+// dimensions. Positions are stored as indices into the Interpreter, so COO supports all of its
+// methods for converting between points and indices.
+//
+// Indices must be sorted in increasing order, with no duplicates. NewCOO and the other functions
+// in this package that return a COO all maintain this.
+type COO struct {
+	Interpreter
+
+	// Indices are the positions of the stored values, as would be given by Interpreter.Index
+	Indices []int
+
+	// Values are the stored values, corresponding to Indices
+	Values []float64
+}
+
+// CSR is a sparse two-dimensional Tensor in compressed sparse row format. As with a dense
+// two-dimensional Tensor, Dims is [cols, rows].
+//
+// The values in row i are Values[RowPtr[i]:RowPtr[i+1]], with their columns given by the same
+// range of Cols. Within each row, Cols are sorted in increasing order.
+type CSR struct {
+	Interpreter
+
+	RowPtr []int
+	Cols   []int
+	Values []float64
+}
+
+// NewCOO returns a new COO with the given dimensions, with each value stored at the corresponding
+// point. Values given for the same point are summed. Neither points nor values are retained.
+//
+// NewCOO will panic if any of the error conditions from NewCOOSafe are met.
+func NewCOO(dims []int, points [][]int, values []float64) COO {
+	c, err := NewCOOSafe(dims, points, values)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// NewCOOSafe undergoes the same process as NewCOO, but returns error instead of panicking. In
+// addition to the errors from NewInterpreterSafe and Interpreter.CheckPoint, NewCOOSafe returns a
+// LengthMismatchError if the number of values does not match the number of points.
+func NewCOOSafe(dims []int, points [][]int, values []float64) (COO, error) {
+	in, err := NewInterpreterSafe(dims)
+	if err != nil {
+		return COO{}, err
+	} else if len(values) != len(points) {
+		return COO{}, LengthMismatchError{"values", len(values), len(points)}
+	}
+
+	order := make([]int, len(points))
+	indices := make([]int, len(points))
+	for i, p := range points {
+		if err := in.CheckPoint(p); err != nil {
+			return COO{}, err
+		}
+
+		order[i] = i
+		indices[i] = in.IndexFast(p)
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return indices[order[i]] < indices[order[j]] })
+
+	c := COO{Interpreter: in}
+	for _, o := range order {
+		if n := len(c.Indices); n != 0 && c.Indices[n-1] == indices[o] {
+			c.Values[n-1] += values[o]
+			continue
+		}
+
+		c.Indices = append(c.Indices, indices[o])
+		c.Values = append(c.Values, values[o])
+	}
+
+	return c, nil
+}
+
+// NNZ returns the number of stored values.
+func (c COO) NNZ() int {
+	return len(c.Values)
+}
+
+// ToCOO returns the nonzero values of t as a COO.
+func (t Tensor) ToCOO() COO {
+	c := COO{Interpreter: t.Interpreter}
+	for i, v := range t.Values {
+		if v != 0 {
+			c.Indices = append(c.Indices, i)
+			c.Values = append(c.Values, v)
+		}
+	}
+
+	return c
+}
+
+// Dense returns c as a dense Tensor.
+func (c COO) Dense() Tensor {
+	t := Tensor{Interpreter: c.Interpreter, Values: make([]float64, c.Size())}
+	for i, index := range c.Indices {
+		t.Values[index] = c.Values[i]
+	}
+
+	return t
+}
+
+// ToCSR converts c to CSR. ToCSR will panic with a LengthMismatchError if c is not
+// two-dimensional. ToCSRSafe returns the error instead.
+func (c COO) ToCSR() CSR {
+	m, err := c.ToCSRSafe()
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// ToCSRSafe undergoes the same process as ToCSR, but returns error instead of panicking.
+func (c COO) ToCSRSafe() (CSR, error) {
+	if err := checkLayout(c.Interpreter); err != nil {
+		return CSR{}, opError("COO.ToCSR", err, c.Interpreter)
+	} else if len(c.Dims) != 2 {
+		return CSR{}, opError("COO.ToCSR", LengthMismatchError{"dims", len(c.Dims), 2},
+			c.Interpreter)
+	}
+
+	cols, rows := c.Dims[0], c.Dims[1]
+	m := CSR{
+		Interpreter: c.Interpreter,
+		RowPtr:      make([]int, rows+1),
+		Cols:        make([]int, len(c.Indices)),
+		Values:      make([]float64, len(c.Values)),
+	}
+
+	// Indices are sorted, which (because Dims[0] varies fastest) orders them by row, then column
+	for i, index := range c.Indices {
+		m.RowPtr[index/cols+1]++
+		m.Cols[i] = index % cols
+	}
+
+	for r := 0; r < rows; r++ {
+		m.RowPtr[r+1] += m.RowPtr[r]
+	}
+
+	copy(m.Values, c.Values)
+	return m, nil
+}
+
+// ToCSR returns the nonzero values of t as a CSR. ToCSR will panic with a LengthMismatchError if t
+// is not two-dimensional. ToCSRSafe returns the error instead.
+func (t Tensor) ToCSR() CSR {
+	return t.ToCOO().ToCSR()
+}
+
+// ToCSRSafe undergoes the same process as ToCSR, but returns error instead of panicking.
+func (t Tensor) ToCSRSafe() (CSR, error) {
+	m, err := t.ToCOO().ToCSRSafe()
+	return m, opError("ToCSR", err, t.Interpreter)
+}
+
+// NNZ returns the number of stored values.
+func (m CSR) NNZ() int {
+	return len(m.Values)
+}
+
+// ToCOO converts m to COO.
+func (m CSR) ToCOO() COO {
+	c := COO{
+		Interpreter: m.Interpreter,
+		Indices:     make([]int, 0, len(m.Values)),
+		Values:      make([]float64, len(m.Values)),
+	}
+
+	cols := m.Dims[0]
+	for r := 0; r+1 < len(m.RowPtr); r++ {
+		for p := m.RowPtr[r]; p < m.RowPtr[r+1]; p++ {
+			c.Indices = append(c.Indices, r*cols+m.Cols[p])
+		}
+	}
+
+	copy(c.Values, m.Values)
+	return c
+}
+
+// Dense returns m as a dense Tensor.
+func (m CSR) Dense() Tensor {
+	return m.ToCOO().Dense()
+}
+
+// MatMul returns the matrix product of m with the dense, two-dimensional Tensor b. Only the stored
+// values of m are visited, so this takes time proportional to NNZ times the number of columns of
+// b. As with the function MatMul, m must have dimensions [k, m], and b [n, k]; the result has
+// dimensions [n, m].
+//
+// MatMul will panic if any of the error conditions from MatMulSafe are met.
+func (m CSR) MatMul(b Tensor) Tensor {
+	return must(m.MatMulSafe(b))
+}
+
+// MatMulSafe undergoes the same process as MatMul, but returns error instead of panicking.
+// MatMulSafe returns a LengthMismatchError if b is not two-dimensional, and a ShapeMismatchError
+// if the number of rows of b does not match the number of columns of m, both wrapped in an OpError.
+func (m CSR) MatMulSafe(b Tensor) (Tensor, error) {
+	var err error
+	if len(b.Dims) != 2 {
+		err = LengthMismatchError{"operand 1 dims", len(b.Dims), 2}
+	} else if m.Dims[0] != b.Dims[1] {
+		err = ShapeMismatchError{1, 1, b.Dims, []int{b.Dims[0], m.Dims[0]}}
+	} else {
+		err = checkLayout(m.Interpreter, b.Interpreter)
+	}
+
+	if err != nil {
+		return Tensor{}, opError("CSR.MatMul", err, m.Interpreter, b.Interpreter)
+	}
+
+	rows, n := m.Dims[1], b.Dims[0]
+	res := NewTensor([]int{n, rows})
+
+	for i := 0; i < rows; i++ {
+		row := res.Values[n*i : n*(i+1)]
+		for p := m.RowPtr[i]; p < m.RowPtr[i+1]; p++ {
+			x, l := m.Values[p], m.Cols[p]
+			for j, y := range b.Values[n*l : n*(l+1)] {
+				row[j] += x * y
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// merge combines the stored values of c and o, which must have the same dimensions. If union is
+// true, positions stored in either are visited, with a missing value given as zero; otherwise,
+// only positions stored in both are. Results that are exactly zero are not stored.
+func (c COO) merge(o COO, union bool, fn func(a, b float64) float64) COO {
+	res := COO{Interpreter: c.Interpreter}
+	add := func(index int, v float64) {
+		if v != 0 {
+			res.Indices = append(res.Indices, index)
+			res.Values = append(res.Values, v)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(c.Indices) || j < len(o.Indices) {
+		switch {
+		case j == len(o.Indices) || (i < len(c.Indices) && c.Indices[i] < o.Indices[j]):
+			if union {
+				add(c.Indices[i], fn(c.Values[i], 0))
+			}
+			i++
+		case i == len(c.Indices) || o.Indices[j] < c.Indices[i]:
+			if union {
+				add(o.Indices[j], fn(0, o.Values[j]))
+			}
+			j++
+		default:
+			add(c.Indices[i], fn(c.Values[i], o.Values[j]))
+			i++
+			j++
+		}
+	}
+
+	return res
+}
+
+// checkSparse returns ErrNotColumnMajor if either of c or o is not ColumnMajor, or a
+// ShapeMismatchError if o does not have the same dimensions as c.
+func (c COO) checkSparse(o Interpreter) error {
+	if err := checkLayout(c.Interpreter, o); err != nil {
+		return err
+	} else if !Equals(c.Interpreter, o) {
+		return ShapeMismatchError{1, -1, o.Dims, c.Dims}
+	}
+
+	return nil
+}
+
+// Add returns the element-wise sum of c and o, which must have the same dimensions. Add will panic
+// with a ShapeMismatchError if they do not. AddSafe returns the error instead.
+func (c COO) Add(o COO) COO {
+	res, err := c.AddSafe(o)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// AddSafe undergoes the same process as Add, but returns error instead of panicking.
+func (c COO) AddSafe(o COO) (COO, error) {
+	if err := c.checkSparse(o.Interpreter); err != nil {
+		return COO{}, opError("COO.Add", err, c.Interpreter, o.Interpreter)
+	}
+
+	return c.merge(o, true, func(a, b float64) float64 { return a + b }), nil
+}
+
+// Sub returns the element-wise difference c - o, which must have the same dimensions. Sub will
+// panic with a ShapeMismatchError if they do not. SubSafe returns the error instead.
+func (c COO) Sub(o COO) COO {
+	res, err := c.SubSafe(o)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// SubSafe undergoes the same process as Sub, but returns error instead of panicking.
+func (c COO) SubSafe(o COO) (COO, error) {
+	if err := c.checkSparse(o.Interpreter); err != nil {
+		return COO{}, opError("COO.Sub", err, c.Interpreter, o.Interpreter)
+	}
+
+	return c.merge(o, true, func(a, b float64) float64 { return a - b }), nil
+}
+
+// Mul returns the element-wise product of c and o, which must have the same dimensions. Only
+// positions stored in both are visited. Mul will panic with a ShapeMismatchError if the
+// dimensions differ. MulSafe returns the error instead.
+func (c COO) Mul(o COO) COO {
+	res, err := c.MulSafe(o)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// MulSafe undergoes the same process as Mul, but returns error instead of panicking.
+func (c COO) MulSafe(o COO) (COO, error) {
+	if err := c.checkSparse(o.Interpreter); err != nil {
+		return COO{}, opError("COO.Mul", err, c.Interpreter, o.Interpreter)
+	}
+
+	return c.merge(o, false, func(a, b float64) float64 { return a * b }), nil
+}
+
+// MulDense returns the element-wise product of c and the dense Tensor t, which must have the same
+// dimensions. The result is sparse, visiting only the positions stored in c. MulDense will panic
+// with a ShapeMismatchError if the dimensions differ. MulDenseSafe returns the error instead.
+func (c COO) MulDense(t Tensor) COO {
+	res, err := c.MulDenseSafe(t)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// MulDenseSafe undergoes the same process as MulDense, but returns error instead of panicking.
+func (c COO) MulDenseSafe(t Tensor) (COO, error) {
+	if err := c.checkSparse(t.Interpreter); err != nil {
+		return COO{}, opError("COO.MulDense", err, c.Interpreter, t.Interpreter)
+	}
+
+	res := COO{Interpreter: c.Interpreter}
+	for i, index := range c.Indices {
+		if v := c.Values[i] * t.Values[index]; v != 0 {
+			res.Indices = append(res.Indices, index)
+			res.Values = append(res.Values, v)
+		}
+	}
+
+	return res, nil
+}
+
+// Scale returns a new COO with every stored value multiplied by f.
+func (c COO) Scale(f float64) COO {
+	res := COO{c.Interpreter, make([]int, len(c.Indices)), make([]float64, len(c.Values))}
+	copy(res.Indices, c.Indices)
+	for i, v := range c.Values {
+		res.Values[i] = v * f
+	}
+
+	return res
+}
+
+// MapApply is the sparse analog to Interpreter.MapApply: it applies fn to each stored value of
+// c, giving its point and its position in c.Values. Positions that are not stored are skipped.
+// Each call to fn receives its own point, which may be modified.
+//
+// Threading options are handled as they are by Interpreter.MapApply, but count only stored
+// values. MapApply will panic with ErrNilFunction if fn is nil.
+func (c COO) MapApply(fn func(point []int, i int), options *ThreadingOptions) {
+	if fn == nil {
+		panic(ErrNilFunction)
+	}
+
+	err := c.MapApplySafe(func(point []int, i int) error {
+		fn(point, i)
+		return nil
+	}, options)
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MapApplySafe undergoes the same process as MapApply, but returns error instead of panicking,
+// and expects fn to return error. Errors returned by fn are passed along without context.
+func (c COO) MapApplySafe(fn func(point []int, i int) error, options *ThreadingOptions) error {
+	if fn == nil {
+		return ErrNilFunction
+	} else if len(c.Indices) == 0 {
+		return nil
+	}
+
+	// the stored values are iterated over as if they were a one-dimensional Interpreter
+	nz, err := NewInterpreterSafe([]int{len(c.Indices)})
+	if err != nil {
+		return err
+	}
+
+	return nz.MapApplySafe(func(_ []int, i int) error {
+		return fn(c.Point(c.Indices[i]), i)
+	}, options)
+}
@@ -0,0 +1,155 @@
+package tensors
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// randomSparse returns a Tensor with roughly the given fraction of its values nonzero
+func randomSparse(dims []int, density float64, rng *rand.Rand) Tensor {
+	t := NewTensor(dims)
+	for i := range t.Values {
+		if rng.Float64() < density {
+			t.Values[i] = rng.Float64()*2 - 1
+		}
+	}
+
+	return t
+}
+
+// requires FromNested
+func tSparse(t *testing.T) {
+	// unsorted, with a duplicate point
+	c, err := NewCOOSafe([]int{3, 2}, [][]int{{2, 1}, {0, 0}, {2, 1}, {1, 1}}, []float64{1, 2, 3, 4})
+	if handleErrors(t, "NewCOO", nil, err, "") {
+		handleReturn(t, "NewCOO", []int{0, 4, 5}, c.Indices, "")
+		handleReturn(t, "NewCOO", []float64{2, 4, 4}, c.Values, "")
+
+		dense := FromNested([][]float64{{2, 0, 0}, {0, 4, 4}})
+		handleReturn(t, "COO.Dense", dense, c.Dense(), "")
+		handleReturn(t, "ToCOO", c, dense.ToCOO(), "")
+
+		m, err := c.ToCSRSafe()
+		if handleErrors(t, "ToCSR", nil, err, "") {
+			handleReturn(t, "ToCSR", []int{0, 1, 3}, m.RowPtr, "")
+			handleReturn(t, "ToCSR", []int{0, 1, 2}, m.Cols, "")
+			handleReturn(t, "CSR.Dense", dense, m.Dense(), "")
+			handleReturn(t, "CSR.ToCOO", c, m.ToCOO(), "")
+		}
+	}
+
+	errTable := []struct {
+		dims   []int
+		points [][]int
+		values []float64
+		err    error
+	}{
+		{[]int{3, 2}, [][]int{{0, 0}}, []float64{1, 2}, LengthMismatchError{}},
+		{[]int{3, 2}, [][]int{{0, 2}}, []float64{1}, PointOutOfBoundsError{}},
+		{[]int{3, 2}, [][]int{{0}}, []float64{1}, LengthMismatchError{}},
+		{[]int{3, 0}, nil, nil, DimsValueError{}},
+	}
+
+	for _, tab := range errTable {
+		_, err := NewCOOSafe(tab.dims, tab.points, tab.values)
+		handleErrors(t, "NewCOO", tab.err, err, "Dims: %v, Points: %v.", tab.dims, tab.points)
+	}
+
+	_, err = NewTensor([]int{2, 2, 2}).ToCSRSafe()
+	handleErrors(t, "ToCSR", LengthMismatchError{}, err, "Dims: [2 2 2].")
+}
+
+// requires Sparse, MatMul
+func tSparseMatMul(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	a := randomSparse([]int{30, 20}, 0.1, rng)
+	b := NewRandom([]int{4, 30}, Uniform(-1, 1), rng)
+
+	res, err := a.ToCSR().MatMulSafe(b)
+	if expected := MatMul(a, b); handleErrors(t, "CSR.MatMul", nil, err, "") &&
+		!approxEqual(expected, res, 1e-12) {
+		t.Errorf("CSR.MatMul: Bad values. Expected %v, Got %v.", expected, res)
+	}
+
+	_, err = a.ToCSR().MatMulSafe(NewTensor([]int{4, 20}))
+	handleErrors(t, "CSR.MatMul", ShapeMismatchError{}, err, "B: [4 20].")
+
+	_, err = a.ToCSR().MatMulSafe(NewTensor([]int{30}))
+	handleErrors(t, "CSR.MatMul", LengthMismatchError{}, err, "B: [30].")
+}
+
+// requires Sparse, Arithmetic
+func tSparseOps(t *testing.T) {
+	rng := rand.New(rand.NewSource(10))
+	a := randomSparse([]int{5, 4, 3}, 0.3, rng)
+	b := randomSparse([]int{5, 4, 3}, 0.3, rng)
+	sa, sb := a.ToCOO(), b.ToCOO()
+
+	table := []struct {
+		name     string
+		fn       func() (COO, error)
+		expected Tensor
+	}{
+		{"Add", func() (COO, error) { return sa.AddSafe(sb) }, Add(a, b)},
+		{"Sub", func() (COO, error) { return sa.SubSafe(sb) }, Sub(a, b)},
+		{"Mul", func() (COO, error) { return sa.MulSafe(sb) }, Mul(a, b)},
+		{"MulDense", func() (COO, error) { return sa.MulDenseSafe(b) }, Mul(a, b)},
+		{"Scale", func() (COO, error) { return sa.Scale(3), nil }, a.Scale(3)},
+	}
+
+	for _, tab := range table {
+		res, err := tab.fn()
+		if handleErrors(t, tab.name, nil, err, "") {
+			handleReturn(t, tab.name, tab.expected, res.Dense(), "")
+			handleReturn(t, tab.name, tab.expected.ToCOO(), res, "Stored values.")
+		}
+	}
+
+	// values that cancel are not stored
+	if res := sa.Sub(sa); res.NNZ() != 0 {
+		t.Errorf("Sub: Expected no stored values, Got %d.", res.NNZ())
+	}
+
+	other := NewTensor([]int{5, 4, 2})
+	_, err := sa.AddSafe(other.ToCOO())
+	handleErrors(t, "Add", ShapeMismatchError{}, err, "Dims: [5 4 2].")
+
+	_, err = sa.MulDenseSafe(other)
+	handleErrors(t, "MulDense", ShapeMismatchError{}, err, "Dims: [5 4 2].")
+}
+
+// requires Sparse, MapApply
+func tSparseMapApply(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	a := randomSparse([]int{6, 5, 4}, 0.2, rng)
+	c := a.ToCOO()
+
+	for _, options := range []*ThreadingOptions{nil, {OpsPerThread: 3, NumThreads: 4}} {
+		var mux sync.Mutex
+		visited := make(map[int]bool)
+
+		err := c.MapApplySafe(func(point []int, i int) error {
+			mux.Lock()
+			defer mux.Unlock()
+
+			index := c.Index(point)
+			if index != c.Indices[i] || visited[index] {
+				t.Errorf("COO.MapApply: Bad call. Point: %v, Position: %d.", point, i)
+			}
+
+			visited[index] = true
+			return nil
+		}, options)
+
+		if handleErrors(t, "COO.MapApply", nil, err, "Options: %v.", options) && len(visited) != c.NNZ() {
+			t.Errorf("COO.MapApply: Expected %d calls, Got %d.", c.NNZ(), len(visited))
+		}
+	}
+
+	err := c.MapApplySafe(nil, nil)
+	handleErrors(t, "COO.MapApply", ErrNilFunction, err, "Nil function.")
+
+	err = c.MapApplySafe(func([]int, int) error { return ErrEmptySelection }, nil)
+	handleErrors(t, "COO.MapApply", ErrEmptySelection, err, "Returned error.")
+}
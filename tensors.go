@@ -8,13 +8,16 @@ type Tensor struct {
 	// Values is the set of the values stored in the Tensor. The description for the storage of
 	// these values can be found in the documentation for Interpreter.Dims
 	Values []float64
+
+	// shared is non-nil if Values may be shared with other Tensors from Share
+	shared *sharing
 }
 
 // NewTensor returns a new Tensor, and will panic if any of the error conditions from
 // NewInterpreterSafe are met.
 func NewTensor(dims []int) Tensor {
 	in := NewInterpreter(dims)
-	return Tensor{in, make([]float64, in.Size())}
+	return Tensor{Interpreter: in, Values: make([]float64, in.Size())}
 }
 
 // NewTensorSafe undergoes the same process as NewTensor, but returns error instead of panicking.
@@ -25,7 +28,39 @@ func NewTensorSafe(dims []int) (Tensor, error) {
 		return Tensor{}, err
 	}
 
-	return Tensor{in, make([]float64, in.Size())}, nil
+	return Tensor{Interpreter: in, Values: make([]float64, in.Size())}, nil
+}
+
+// NewTensorExtended returns a new Tensor with the Interpreter given by NewInterpreterExtended, so
+// that it may have no dimensions (holding a single value) or dimensions of size zero (holding no
+// values). NewTensorExtended will panic if any of the error conditions from
+// NewInterpreterExtendedSafe are met.
+func NewTensorExtended(dims []int) Tensor {
+	return must(NewTensorExtendedSafe(dims))
+}
+
+// NewTensorExtendedSafe undergoes the same process as NewTensorExtended, but returns error instead
+// of panicking.
+func NewTensorExtendedSafe(dims []int) (Tensor, error) {
+	in, err := NewInterpreterExtendedSafe(dims)
+	if err != nil {
+		return Tensor{}, err
+	}
+
+	return Tensor{Interpreter: in, Values: make([]float64, in.Size())}, nil
+}
+
+// Validate checks that the Tensor is consistent, as is done by Interpreter.Validate, and
+// additionally that it has the correct number of Values. If it does not, Validate returns a
+// LengthMismatchError.
+func (t Tensor) Validate() error {
+	if err := t.Interpreter.Validate(); err != nil {
+		return err
+	} else if len(t.Values) != t.Size() {
+		return LengthMismatchError{"values", len(t.Values), t.Size()}
+	}
+
+	return nil
 }
 
 // PointValue returns the value of the tensor at the given point. PointValue requires the same
@@ -44,3 +79,140 @@ func (t Tensor) PointValueSafe(point []int) (float64, error) {
 
 	return t.Values[index], nil
 }
+
+// SetPoint sets the value of the tensor at the given point. SetPoint requires the same conditions
+// as Interpreter.Index (and thus, Interpreter.CheckPoint). If the Values of the Tensor are shared
+// through Share, the Tensor first receives its own copy.
+func (t *Tensor) SetPoint(point []int, value float64) {
+	if err := t.SetPointSafe(point, value); err != nil {
+		panic(err)
+	}
+}
+
+// SetPointSafe undergoes the same process as SetPoint, but will return error instead of
+// panicking.
+func (t *Tensor) SetPointSafe(point []int, value float64) error {
+	index, err := t.IndexSafe(point)
+	if err != nil {
+		return err
+	}
+
+	t.Unshare()
+	t.Values[index] = value
+	return nil
+}
+
+// Clone returns a deep copy of the Tensor: its Dims, Sizes and Values are all copied, so nothing
+// is shared with the original.
+func (t Tensor) Clone() Tensor {
+	c := Tensor{Interpreter: t.Interpreter.Clone(), Values: make([]float64, len(t.Values))}
+	copy(c.Values, t.Values)
+	return c
+}
+
+// CopyFrom copies the values of src into the Tensor, which must have the same dimensions. If the
+// Values of the Tensor are shared through Share, the Tensor first receives its own copy. src may
+// have a different Layout, in which case its values are copied by point, as with ToLayout.
+//
+// CopyFrom will panic with a ShapeMismatchError (naming src as operand 1) if the dimensions of the
+// two Tensors are not equal. CopyFromSafe returns the error instead.
+func (t *Tensor) CopyFrom(src Tensor) {
+	if err := t.CopyFromSafe(src); err != nil {
+		panic(err)
+	}
+}
+
+// CopyFromSafe undergoes the same process as CopyFrom, but returns error instead of panicking.
+func (t *Tensor) CopyFromSafe(src Tensor) error {
+	if !Equals(t.Interpreter, src.WithLayout(t.Layout)) {
+		return opError("CopyFrom", ShapeMismatchError{1, -1, src.Dims, t.Dims}, t.Interpreter,
+			src.Interpreter)
+	} else if src.Layout != t.Layout {
+		src = src.ToLayout(t.Layout)
+	}
+
+	t.Unshare()
+	copy(t.Values, src.Values)
+	return nil
+}
+
+// SetRegion copies the values of src into the region of the Tensor that starts at the given point
+// and has the same dimensions as src. SetRegion is the counterpart to Crop. If the Values of the
+// Tensor are shared through Share, the Tensor first receives its own copy.
+//
+// SetRegion will panic if any of the error conditions from SetRegionSafe are met.
+func (t *Tensor) SetRegion(start []int, src Tensor) {
+	if err := t.SetRegionSafe(start, src); err != nil {
+		panic(err)
+	}
+}
+
+// SetRegionSafe undergoes the same process as SetRegion, but returns error instead of panicking.
+// SetRegionSafe returns:
+//		(0) Any error from Interpreter.CheckPoint for start
+//		(1) A LengthMismatchError if src does not have the same number of dimensions as the Tensor
+//		(2) A PointOutOfBoundsError, giving the last point of the region, if the region extends
+//			past the end of the Tensor along any axis
+// Each is wrapped in an OpError.
+func (t *Tensor) SetRegionSafe(start []int, src Tensor) error {
+	if err := t.checkRegion(start, src); err != nil {
+		return opError("SetRegion", err, t.Interpreter, src.Interpreter)
+	}
+
+	t.Unshare()
+
+	// a rank-0 region is the single value of both Tensors
+	if len(src.Dims) == 0 {
+		copy(t.Values, src.Values)
+		return nil
+	}
+
+	// copy each contiguous run along Dims[0] at once. Runs are only contiguous if both Tensors are
+	// ColumnMajor; otherwise, each value is copied separately.
+	run := src.Dims[0]
+	contiguous := t.Layout == ColumnMajor && src.Layout == ColumnMajor
+	if !contiguous {
+		run = 1
+	}
+
+	point := make([]int, len(src.Dims))
+	target := make([]int, len(start))
+	for i := 0; i < len(src.Values); i += run {
+		for a := range point {
+			target[a] = start[a] + point[a]
+		}
+
+		index := t.IndexFast(target)
+		copy(t.Values[index:index+run], src.Values[i:i+run])
+
+		if contiguous {
+			point[0] = run - 1
+		}
+		src.IncrementFast(point)
+	}
+
+	return nil
+}
+
+// checkRegion checks that the region starting at start, with the dimensions of src, is within the
+// bounds of the Tensor.
+func (t Tensor) checkRegion(start []int, src Tensor) error {
+	if err := t.CheckPoint(start); err != nil {
+		return err
+	} else if len(src.Dims) != len(t.Dims) {
+		return LengthMismatchError{"region dims", len(src.Dims), len(t.Dims)}
+	}
+
+	end := make([]int, len(start))
+	for i := range start {
+		end[i] = start[i] + src.Dims[i] - 1
+	}
+
+	for i := range end {
+		if end[i] >= t.Dims[i] {
+			return PointOutOfBoundsError{end, t.Dims, i}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package tensors
+
+import (
+	"testing"
+)
+
+// requires FromNested
+func tClone(t *testing.T) {
+	a := FromNested([][]float64{{1, 2, 3}, {4, 5, 6}})
+	c := a.Clone()
+	handleReturn(t, "Clone", a, c, "")
+
+	// nothing is shared with the original
+	c.Dims[0], c.Sizes[0], c.Values[0] = 7, 7, 7
+	handleReturn(t, "Clone", FromNested([][]float64{{1, 2, 3}, {4, 5, 6}}), a, "Original after changes.")
+
+	dims := []int{2, 3}
+	in := NewInterpreter(dims).Clone()
+	dims[0] = 5
+	handleReturn(t, "Interpreter.Clone", []int{2, 3}, in.Dims, "")
+}
+
+// requires Clone, Share
+func tCopyFrom(t *testing.T) {
+	a := NewTensor([]int{2, 2})
+	src := FromNested([][]float64{{1, 2}, {3, 4}})
+
+	if err := a.CopyFromSafe(src); handleErrors(t, "CopyFrom", nil, err, "") {
+		handleReturn(t, "CopyFrom", src, a, "")
+		if &a.Values[0] == &src.Values[0] {
+			t.Errorf("CopyFrom: Values were aliased instead of copied.")
+		}
+	}
+
+	// copying into a shared Tensor leaves the others unchanged
+	b := a.Share()
+	b.CopyFrom(NewTensor([]int{2, 2}))
+	handleReturn(t, "CopyFrom", src.Values, a.Values, "Shared.")
+
+	err := a.CopyFromSafe(NewTensor([]int{4}))
+	handleErrors(t, "CopyFrom", ShapeMismatchError{}, err, "Dims: [4].")
+}
+
+// requires Index, Share
+func tSetPoint(t *testing.T) {
+	a := NewTensor([]int{2, 3, 4})
+	b := a.Share()
+
+	table := []struct {
+		point []int
+		err   error
+	}{
+		{[]int{0, 1, 2}, nil},
+		{[]int{1, 2, 3}, nil},
+
+		{nil, ErrZeroPoint},
+		{[]int{0, 0}, LengthMismatchError{}},
+		{[]int{2, 0, 0}, PointOutOfBoundsError{}},
+	}
+
+	for i, tab := range table {
+		err := a.SetPointSafe(tab.point, float64(i+1))
+		if handleErrors(t, "SetPoint", tab.err, err, "Point: %v.", tab.point) {
+			handleReturn(t, "SetPoint", float64(i+1), a.PointValue(tab.point), "Point: %v.", tab.point)
+		}
+	}
+
+	handleReturn(t, "SetPoint", NewTensor([]int{2, 3, 4}).Values, b.Values, "Shared.")
+}
+
+// requires FromNested, Crop
+func tSetRegion(t *testing.T) {
+	src := FromNested([][]float64{{1, 2}, {3, 4}})
+
+	table := []struct {
+		start    []int
+		src      Tensor
+		expected Tensor
+		err      error
+	}{
+		{[]int{0, 0}, src, FromNested([][]float64{{1, 2, 0}, {3, 4, 0}, {0, 0, 0}}), nil},
+		{[]int{1, 1}, src, FromNested([][]float64{{0, 0, 0}, {0, 1, 2}, {0, 3, 4}}), nil},
+		{[]int{0, 2}, FromNested([][]float64{{5, 6, 7}}),
+			FromNested([][]float64{{0, 0, 0}, {0, 0, 0}, {5, 6, 7}}), nil},
+
+		{[]int{2, 2}, src, Tensor{}, PointOutOfBoundsError{}},
+		{[]int{3, 0}, src, Tensor{}, PointOutOfBoundsError{}},
+		{[]int{0}, src, Tensor{}, LengthMismatchError{}},
+		{[]int{0, 0}, FromNested([]float64{1}), Tensor{}, LengthMismatchError{}},
+	}
+
+	for _, tab := range table {
+		a := NewTensor([]int{3, 3})
+		err := a.SetRegionSafe(tab.start, tab.src)
+		if handleErrors(t, "SetRegion", tab.err, err, "Start: %v, Src: %v.", tab.start, tab.src) {
+			handleReturn(t, "SetRegion", tab.expected, a, "Start: %v, Src: %v.", tab.start, tab.src)
+		}
+	}
+
+	// SetRegion is the counterpart to Crop
+	a := NewTensor([]int{4, 3, 2})
+	b := FromNested([][][]float64{{{1, 2}, {3, 4}}})
+	a.SetRegion([]int{1, 1, 1}, b)
+	handleReturn(t, "SetRegion", b, Crop(a, []int{1, 1, 1}, []int{1, 0, 0}), "Crop.")
+
+	// a rank-0 region is the single value of the Tensor
+	s := Scalar(1)
+	err := s.SetRegionSafe([]int{}, Scalar(2))
+	_ = handleErrors(t, "SetRegion", nil, err, "Rank 0.") &&
+		handleReturn(t, "SetRegion", Scalar(2), s, "Rank 0.")
+}